@@ -0,0 +1,40 @@
+package configuration
+
+// TasksByFileType returns, for every type of every enabled File definition, the enabled tasks whose include patterns may match files of that type; tasks keep their declaration order
+func (c *Configuration) TasksByFileType() map[string][]*Task {
+	if c == nil {
+		return nil
+	}
+	affinity := map[string][]*Task{}
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file := c.resolveFile(f)
+		if file.Disabled {
+			continue
+		}
+		for _, fileType := range file.Type {
+			if _, ok := affinity[fileType]; ok {
+				continue
+			}
+			affinity[fileType] = []*Task{}
+			for _, t := range c.Task {
+				if t == nil {
+					continue
+				}
+				task := c.resolve(t)
+				if task.Disabled || task.Path == nil {
+					continue
+				}
+				for _, include := range positive(task.Path.Include) {
+					if intersects(include, "**/*."+fileType) && !coveredByAny(task.Path.Exclude, include) {
+						affinity[fileType] = append(affinity[fileType], t)
+						break
+					}
+				}
+			}
+		}
+	}
+	return affinity
+}
@@ -0,0 +1,60 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_TasksByFileType(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "go",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+				},
+			},
+			{
+				Name: "all",
+				Path: &configuration.Path{
+					Include: []string{"src/**"},
+				},
+			},
+			{
+				Name: "docs",
+				Path: &configuration.Path{
+					Include: []string{"docs/*.md"},
+				},
+			},
+			{
+				Name: "excluded",
+				Path: &configuration.Path{
+					Include: []string{"vendor/**/*.go"},
+					Exclude: []string{"vendor/**"},
+				},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+			},
+			{
+				Type: []string{"md", "js"},
+			},
+		},
+	}
+	affinity := c.TasksByFileType()
+	if len(affinity) != 3 {
+		t.Fatalf("Expecting 3 types, got %v", affinity)
+	}
+	if len(affinity["go"]) != 2 || affinity["go"][0].Name != "go" || affinity["go"][1].Name != "all" {
+		t.Errorf("Expecting go and all tasks, got %v", affinity["go"])
+	}
+	if len(affinity["md"]) != 2 || affinity["md"][1].Name != "docs" {
+		t.Errorf("Expecting all and docs tasks, got %v", affinity["md"])
+	}
+	if len(affinity["js"]) != 1 {
+		t.Errorf("Expecting all task, got %v", affinity["js"])
+	}
+}
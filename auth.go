@@ -0,0 +1,86 @@
+package configuration
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// WithBearerToken authenticates the remote requests of loading with the bearer token, overriding EMITS_TOKEN; credentials are only ever sent over https to the authorized hosts of WithAuthHosts
+func WithBearerToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithBasicAuth authenticates the remote requests of loading with the username and password, overriding EMITS_USERNAME and EMITS_PASSWORD; credentials are only ever sent over https to the authorized hosts of WithAuthHosts
+func WithBasicAuth(username string, password string) Option {
+	return WithHeader("Authorization", basicAuth(username, password))
+}
+
+// basicAuth returns the Authorization header value of the username and password
+func basicAuth(username string, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// WithHeader sets the header on the remote requests of loading, such as an API key required by a private endpoint, sent like credentials; a later WithHeader of the same name replaces it
+func WithHeader(name string, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(name, value)
+	}
+}
+
+// WithAuthHosts authorizes the hosts, such as `config.example.com` or `config.example.com:8443`, to receive the credentials of loading besides the host of the location passed to LoadFrom or URLSource, and those listed comma separated in EMITS_AUTH_HOSTS; a location extended or included from another host never receives them
+func WithAuthHosts(hosts ...string) Option {
+	return func(o *options) {
+		o.authHosts = append(o.authHosts, hosts...)
+	}
+}
+
+// withAuthLocation authorizes the host of the remote location being loaded to receive the credentials of loading
+func withAuthLocation(location string) Option {
+	return func(o *options) {
+		if u, err := url.Parse(location); err == nil && len(u.Host) > 0 {
+			o.authHosts = append(o.authHosts, u.Host)
+		}
+	}
+}
+
+// authorized returns true if the location is an https url of an authorized host
+func (o *options) authorized(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	hosts := o.authHosts
+	if env := os.Getenv("EMITS_AUTH_HOSTS"); len(env) > 0 {
+		hosts = append(append([]string{}, hosts...), strings.Split(env, ",")...)
+	}
+	for _, host := range hosts {
+		host = strings.TrimSpace(host)
+		if strings.EqualFold(host, u.Host) || strings.EqualFold(host, u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
+
+// headers returns the headers of a remote request of the location: the Authorization of EMITS_TOKEN, or of EMITS_USERNAME and EMITS_PASSWORD, overridden by every WithHeader; none unless the location is authorized
+func (o *options) headers(location string) http.Header {
+	header := http.Header{}
+	if !o.authorized(location) {
+		return header
+	}
+	if token := os.Getenv("EMITS_TOKEN"); len(token) > 0 {
+		header.Set("Authorization", "Bearer "+token)
+	} else if username := os.Getenv("EMITS_USERNAME"); len(username) > 0 {
+		header.Set("Authorization", basicAuth(username, os.Getenv("EMITS_PASSWORD")))
+	}
+	for name, values := range o.header {
+		header[name] = values
+	}
+	return header
+}
@@ -0,0 +1,152 @@
+package configuration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestWithBearerToken(t *testing.T) {
+	cacheDir, client := configuration.CacheDir, configuration.HTTPClient
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.HTTPClient = client
+	}()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		switch {
+		case r.Header.Get("Authorization") == "Bearer secret":
+		case username == "emits" && password == "secret":
+		case r.Header.Get("X-Api-Key") == "secret":
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"name": "private"}`))
+	}))
+	defer server.Close()
+	configuration.HTTPClient = server.Client()
+	host := server.Listener.Addr().String()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"extends": "`+server.URL+`/base.json"}`), 0644)
+	tests := []struct {
+		name    string
+		options []configuration.Option
+		env     map[string]string
+		loaded  bool
+	}{
+		{"anonymous", []configuration.Option{configuration.WithAuthHosts(host)}, nil, false},
+		{"unauthorized host", []configuration.Option{configuration.WithBearerToken("secret")}, nil, false},
+		{"bearer", []configuration.Option{configuration.WithBearerToken("secret"), configuration.WithAuthHosts(host)}, nil, true},
+		{"basic", []configuration.Option{configuration.WithBasicAuth("emits", "secret"), configuration.WithAuthHosts(host)}, nil, true},
+		{"header", []configuration.Option{configuration.WithHeader("X-Api-Key", "secret"), configuration.WithAuthHosts(host)}, nil, true},
+		{"token env", nil, map[string]string{"EMITS_TOKEN": "secret", "EMITS_AUTH_HOSTS": "example.com, " + host}, true},
+		{"basic env", nil, map[string]string{"EMITS_USERNAME": "emits", "EMITS_PASSWORD": "secret", "EMITS_AUTH_HOSTS": host}, true},
+		{"option overrides env", []configuration.Option{configuration.WithBearerToken("wrong")}, map[string]string{"EMITS_TOKEN": "secret", "EMITS_AUTH_HOSTS": host}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.CacheDir = t.TempDir()
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			c := &configuration.Configuration{}
+			err := c.LoadFrom(dir, test.options...)
+			if test.loaded && (err != nil || c.Name != "private") {
+				t.Errorf("Expecting private, got %v %v", err, c.Name)
+			}
+			if !test.loaded && err == nil {
+				t.Errorf("Expecting unauthorized error, got nil")
+			}
+		})
+	}
+	configuration.CacheDir = t.TempDir()
+	if err := (&configuration.Configuration{}).LoadFrom(server.URL+"/base.json", configuration.WithBearerToken("secret")); err != nil {
+		t.Errorf("Expecting host of the loaded location authorized, got %v", err)
+	}
+	if _, _, err := configuration.Compose(configuration.URLSource(server.URL+"/base.json", configuration.WithBearerToken("secret"))); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+}
+
+func TestWithBearerToken_FetchPlugins(t *testing.T) {
+	cacheDir, client := configuration.CacheDir, configuration.HTTPClient
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.HTTPClient = client
+	}()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("module.exports = {}"))
+	}))
+	defer server.Close()
+	configuration.HTTPClient = server.Client()
+	c := &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{Path: server.URL + "/plugin.js"},
+					},
+				},
+			},
+		},
+	}
+	if _, err := c.FetchPlugins(t.TempDir(), configuration.WithBearerToken("secret")); err == nil {
+		t.Errorf("Expecting unauthorized error, got nil")
+	}
+	plugins, err := c.FetchPlugins(t.TempDir(), configuration.WithBearerToken("secret"), configuration.WithAuthHosts(server.Listener.Addr().String()))
+	if err != nil || len(plugins) != 1 {
+		t.Errorf("Expecting plugin fetched with credentials, got %v %v", plugins, err)
+	}
+}
+
+func TestWithBearerToken_Leak(t *testing.T) {
+	cacheDir, client := configuration.CacheDir, configuration.HTTPClient
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.HTTPClient = client
+	}()
+	var leaked []string
+	collect := func(w http.ResponseWriter, r *http.Request) {
+		if len(r.Header.Get("Authorization")) > 0 || len(r.Header.Get("X-Api-Key")) > 0 {
+			leaked = append(leaked, r.Host)
+		}
+		w.Write([]byte(`{"name": "collected"}`))
+	}
+	third := httptest.NewTLSServer(http.HandlerFunc(collect))
+	defer third.Close()
+	plain := httptest.NewServer(http.HandlerFunc(collect))
+	defer plain.Close()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect.json":
+			http.Redirect(w, r, third.URL+"/redirected.json", http.StatusFound)
+		default:
+			w.Write([]byte(`{"extends": "` + third.URL + `/base.json", "include": ["` + plain.URL + `/fragment.json"]}`))
+		}
+	}))
+	server.TLS = third.TLS
+	server.StartTLS()
+	defer server.Close()
+	configuration.HTTPClient = third.Client()
+	configuration.HTTPClient.CheckRedirect = client.CheckRedirect
+	for _, location := range []string{server.URL + "/emits.json", server.URL + "/redirect.json", plain.URL + "/emits.json"} {
+		if err := (&configuration.Configuration{}).LoadFrom(location, configuration.WithBearerToken("secret"), configuration.WithHeader("X-Api-Key", "secret")); err != nil {
+			t.Errorf("Expecting nil, got %v", err)
+		}
+	}
+	if leaked != nil {
+		t.Errorf("Expecting no credentials sent to other hosts or over http, got %v", leaked)
+	}
+}
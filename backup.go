@@ -0,0 +1,41 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// backupPath returns the name of the i-th most recent backup of path: BackupSuffix, then BackupSuffix followed by `.1`, `.2` and so on
+func backupPath(path string, i int) string {
+	if i == 0 {
+		return path + BackupSuffix
+	}
+	return fmt.Sprintf("%s%s.%d", path, BackupSuffix, i)
+}
+
+// rotateBackups keeps a copy of the file at path as its most recent backup before it is replaced by data, shifting older backups and discarding any beyond n; nothing is kept when n is zero, the file does not exist or already holds data
+func rotateBackups(path string, data []byte, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	current, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, data) {
+		return nil
+	}
+	os.Remove(backupPath(path, n-1))
+	for i := n - 2; i >= 0; i-- {
+		if err := os.Rename(backupPath(path, i), backupPath(path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return writeAtomic(backupPath(path, 0), current, info.Mode().Perm())
+}
@@ -0,0 +1,37 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestWithBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "emits.json")
+	c := &configuration.Configuration{}
+	for _, version := range []string{"1", "2", "2", "3", "4"} {
+		c.Version = version
+		if err := c.WriteToPath(path, configuration.WithBackups(2)); err != nil {
+			t.Fatalf("Expecting nil, got %v", err)
+		}
+	}
+	for name, version := range map[string]string{".bak": "3", ".bak.1": "2"} {
+		backup := &configuration.Configuration{}
+		if err := backup.LoadFrom(path + name); err != nil || backup.Version != version {
+			t.Errorf("Expecting %v backup of version %v, got %v %v", name, version, backup.Version, err)
+		}
+	}
+	if _, err := os.Stat(path + ".bak.2"); !os.IsNotExist(err) {
+		t.Errorf("Expecting 2 backups, got %v", err)
+	}
+	c.Version = "5"
+	if err := c.WriteSectionToPath(path, "version", configuration.WithBackups(2)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	backup := &configuration.Configuration{}
+	if err := backup.LoadFrom(path + ".bak"); err != nil || backup.Version != "4" {
+		t.Errorf("Expecting backup of version 4, got %v %v", backup.Version, err)
+	}
+}
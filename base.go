@@ -0,0 +1,181 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// assemble merges the fragments Configuration includes, then merges Configuration over the base configuration file it extends; linked files resolve relative to dir and chain tracks the absolute path of every visited file for cycle detection
+func (c *Configuration) assemble(dir string, chain []string, options ...Option) error {
+	if err := c.includeFragments(dir, chain, options...); err != nil {
+		return err
+	}
+	return c.extendBase(dir, chain, options...)
+}
+
+// linkedPath returns the location of a file linked by Include or Extends resolved relative to dir, and its absolute location; a url, or a name linked from a remote document, resolves to a url, and a name linked from a git document to a file of the same repository and ref
+func linkedPath(dir string, name string) (string, string, error) {
+	if remote(name) || isGit(name) {
+		return name, name, nil
+	}
+	if isGit(dir) {
+		location, err := gitJoin(dir, name)
+		return location, location, err
+	}
+	if remote(dir) {
+		base, err := url.Parse(dir)
+		if err != nil {
+			return "", "", err
+		}
+		reference, err := url.Parse(name)
+		if err != nil {
+			return "", "", err
+		}
+		location := base.ResolveReference(reference).String()
+		return location, location, nil
+	}
+	path := filepath.FromSlash(name)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	absolute, err := filepath.Abs(path)
+	return path, absolute, err
+}
+
+// linkedDir returns the dir the links of the document at location resolve relative to; the location itself for a remote or git document
+func linkedDir(location string) string {
+	if remote(location) || isGit(location) {
+		return location
+	}
+	return filepath.Dir(location)
+}
+
+// decodeLinked decodes the file or url at location into Configuration after verifying its content against the pinned sha256 sum, if any; remote documents are cached for RemoteTTL and downloaded again once when the cached copy does not match the pin
+func (c *Configuration) decodeLinked(location string, sum string, options ...Option) error {
+	if isGit(location) {
+		return c.decodeGit(location, sum, options...)
+	}
+	if !remote(location) {
+		return c.decodePinned(location, sum, options...)
+	}
+	o := newOptions(options)
+	data, err := fetchCached(o, location)
+	if err != nil {
+		return err
+	}
+	if verifyPin(location, data, sum) != nil && !Offline {
+		if data, err = revalidate(o, location, true); err != nil {
+			return err
+		}
+	}
+	if err := verifyPin(location, data, sum); err != nil {
+		return err
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+	return c.read(bytes.NewReader(data), codecFor(u.Path), "", o)
+}
+
+// decodeGit decodes the file at the git location into Configuration after verifying its content against the pinned sha256 sum, if any; the file is cached for RemoteTTL and fetched again once when the cached copy does not match the pin
+func (c *Configuration) decodeGit(location string, sum string, options ...Option) error {
+	o := newOptions(options)
+	data, err := fetchGit(o, location, false)
+	if err != nil {
+		return err
+	}
+	if verifyPin(location, data, sum) != nil && !Offline {
+		if data, err = fetchGit(o, location, true); err != nil {
+			return err
+		}
+	}
+	if err := verifyPin(location, data, sum); err != nil {
+		return err
+	}
+	_, _, file, err := splitGit(location)
+	if err != nil {
+		return err
+	}
+	return c.read(bytes.NewReader(data), codecFor(file), "", newOptions(options))
+}
+
+// extendBase merges Configuration over the base configuration file its Extends names, resolved relative to dir; chain tracks the absolute path of every visited file for cycle detection
+func (c *Configuration) extendBase(dir string, chain []string, options ...Option) error {
+	if len(c.Extends) == 0 {
+		return nil
+	}
+	name, sum := splitPin(c.Extends)
+	path, absolute, err := linkedPath(dir, name)
+	if err != nil {
+		return err
+	}
+	if contains(chain, absolute) {
+		return fmt.Errorf("extends cycle `%s`", strings.Join(append(chain, absolute), " > "))
+	}
+	base := &Configuration{}
+	if err := base.decodeLinked(path, sum, options...); err != nil {
+		return fmt.Errorf("extends `%s`: %w", c.Extends, err)
+	}
+	if err := base.assemble(linkedDir(path), append(chain, absolute), options...); err != nil {
+		return err
+	}
+	base.Merge(c)
+	base.Extends = c.Extends
+	base.Include = c.Include
+	base.changelog = c.changelog
+	*c = *base
+	return nil
+}
+
+// RefreshRemote downloads every remote file Configuration extends or includes again, ignoring RemoteTTL and ETag, following the links of every linked file; local links resolve relative to the working directory and options may authenticate the requests
+func (c *Configuration) RefreshRemote(options ...Option) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	return c.refreshLinks(".", map[string]bool{}, options...)
+}
+
+// refreshLinks downloads every remote file linked by Configuration again and follows the links of every linked file once
+func (c *Configuration) refreshLinks(dir string, seen map[string]bool, options ...Option) error {
+	links := append([]string{}, c.Include...)
+	if len(c.Extends) > 0 {
+		links = append(links, c.Extends)
+	}
+	for _, link := range links {
+		name, sum := splitPin(link)
+		location, absolute, err := linkedPath(dir, name)
+		if err != nil {
+			return err
+		}
+		if seen[absolute] {
+			continue
+		}
+		seen[absolute] = true
+		linked := &Configuration{}
+		if remote(location) || isGit(location) {
+			if Offline {
+				return &OfflineError{URL: location}
+			}
+			var err error
+			if isGit(location) {
+				_, err = fetchGit(newOptions(options), location, true)
+			} else {
+				_, err = revalidate(newOptions(options), location, true)
+			}
+			if err != nil {
+				return fmt.Errorf("refresh `%s`: %w", location, err)
+			}
+		}
+		if err := linked.decodeLinked(location, sum, options...); err != nil {
+			return fmt.Errorf("refresh `%s`: %w", location, err)
+		}
+		if err := linked.refreshLinks(linkedDir(location), seen, options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadExtends(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "shared"), 0755)
+	os.MkdirAll(filepath.Join(dir, "project"), 0755)
+	os.WriteFile(filepath.Join(dir, "shared", "base.json"), []byte(`{
+		"name": "base",
+		"task": [{"name": "lorem", "path": {"include": ["*.go"]}}, {"name": "ipsum", "path": {"include": ["*.js"]}}],
+		"file": [{"type": ["go"], "parse": {"comment": {"line": "//"}}}]
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "project", "emits.json"), []byte(`{
+		"extends": "../shared/base.json",
+		"name": "project",
+		"task": [{"name": "ipsum", "path": {"include": ["src/*.js"]}}],
+		"script": [{"name": "build", "task": ["lorem", "ipsum"]}]
+	}`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(filepath.Join(dir, "project")); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "project" || c.Extends != "../shared/base.json" {
+		t.Errorf("Expecting project extending ../shared/base.json, got %v %v", c.Name, c.Extends)
+	}
+	if len(c.Task) != 2 || c.FindTask("ipsum").Path.Include[0] != "src/*.js" {
+		t.Errorf("Expecting ipsum replaced, got %v", c.Task)
+	}
+	if len(c.File) != 1 || len(c.Script) != 1 {
+		t.Errorf("Expecting base file and project script, got %v %v", c.File, c.Script)
+	}
+	os.WriteFile(filepath.Join(dir, "shared", "base.json"), []byte(`{"extends": "../project/emits.json"}`), 0644)
+	err := c.LoadFrom(filepath.Join(dir, "project"))
+	if err == nil || !strings.Contains(err.Error(), "extends cycle") {
+		t.Errorf("Expecting extends cycle, got %v", err)
+	}
+}
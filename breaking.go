@@ -0,0 +1,47 @@
+package configuration
+
+const (
+	// ImpactBreaking constant for a Change that may break scripts, tooling or the output of existing files
+	ImpactBreaking = "breaking"
+	// ImpactCompatible constant for a Change that keeps every existing reference and file type working
+	ImpactCompatible = "compatible"
+)
+
+// BreakingChanges returns the changes of Diff from old to new whose Impact is breaking: removed tasks, scripts and vars, file types no longer handled, removed or changed plugins, a changed schema version and tasks gaining a required param
+func BreakingChanges(old, new *Configuration) []*Change {
+	var breaking []*Change
+	for _, change := range old.Diff(new) {
+		if change.Impact == ImpactBreaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// impact returns whether the Change of Diff is breaking or compatible
+func impact(change *Change) string {
+	switch {
+	case change.Action == ChangeRemoved,
+		change.Element == "plugin path",
+		change.Element == "plugin" && change.Action == ChangeChanged,
+		change.Element == "field" && change.Name == "schemaVersion":
+		return ImpactBreaking
+	}
+	return ImpactCompatible
+}
+
+// requiresNewParam returns true if the changed Task requires a param without a default that the Task before did not require
+func requiresNewParam(before *Task, after *Task) bool {
+	for _, param := range after.Param {
+		if param == nil {
+			continue
+		}
+		if !param.Required || len(param.Default) > 0 {
+			continue
+		}
+		if previous := before.FindParam(param.Name); previous == nil || !previous.Required {
+			return true
+		}
+	}
+	return false
+}
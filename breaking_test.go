@@ -0,0 +1,51 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	old := &configuration.Configuration{
+		Version: "1.0.0",
+		Task:    []*configuration.Task{{Name: "docs"}, {Name: "lint"}},
+		File: []*configuration.File{
+			{Type: []string{"go"}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "a.js"}}}},
+			{Type: []string{"md"}},
+		},
+	}
+	new := &configuration.Configuration{
+		Version: "2.0.0",
+		Task: []*configuration.Task{
+			{Name: "docs", Param: []*configuration.Param{{Name: "out", Required: true}}},
+			{Name: "lint", Notes: "ipsum"},
+			{Name: "test"},
+		},
+		File: []*configuration.File{
+			{Type: []string{"go"}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "b.js"}}}},
+		},
+	}
+	expected := []string{
+		"changed task docs",
+		"removed file type md",
+		"changed plugin path b.js",
+	}
+	changes := configuration.BreakingChanges(old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("Expecting %v breaking changes, got %v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if change.String() != expected[i] || change.Impact != configuration.ImpactBreaking {
+			t.Errorf("Expecting breaking %v, got %v %v", expected[i], change.Impact, change)
+		}
+	}
+	for _, change := range old.Diff(new) {
+		if change.Name == "test" && change.Impact != configuration.ImpactCompatible {
+			t.Errorf("Expecting compatible added task, got %v", change.Impact)
+		}
+	}
+	if changes := configuration.BreakingChanges(new, old); len(changes) != 2 {
+		t.Errorf("Expecting removed task and changed plugin, got %v", changes)
+	}
+}
@@ -0,0 +1,136 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// Offline resolves every remote resource strictly from CacheDir; defaults to true when EMITS_OFFLINE is set
+	Offline = len(os.Getenv("EMITS_OFFLINE")) > 0
+	// CacheDir holds the local copy of every remote resource fetched unless WithCacheDir names another; the UserDirs cache directory at the time of each fetch is used when empty
+	CacheDir = ""
+	// RemoteTTL is how long the CacheDir copy of a remote extends or include is used before it is revalidated with its ETag
+	RemoteTTL = time.Hour
+	// ErrOffline is the error class of every remote resource missing from CacheDir while Offline
+	ErrOffline = errors.New("not available offline")
+)
+
+// validators lists every response header a CacheDir copy is revalidated with, the conditional request header it is sent back in and the suffix of the CacheDir file keeping it
+var validators = []struct {
+	header    string
+	condition string
+	suffix    string
+}{
+	{"ETag", "If-None-Match", ".etag"},
+	{"Last-Modified", "If-Modified-Since", ".modified"},
+}
+
+// OfflineError contains the url of a remote resource missing from CacheDir while Offline
+type OfflineError struct {
+	URL string
+}
+
+// Error returns the url of the missing resource
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("`%s` is not cached and is %v", e.URL, ErrOffline)
+}
+
+// Unwrap returns ErrOffline so errors.Is identifies every OfflineError
+func (e *OfflineError) Unwrap() error {
+	return ErrOffline
+}
+
+// cachePath returns the file of the cache dir holding the url
+func cachePath(dir string, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, hex.EncodeToString(sum[:]))
+}
+
+// cached returns the cache dir copy of the url; while Offline a missing copy is an OfflineError
+func cached(dir string, url string) ([]byte, error) {
+	data, err := ioutil.ReadFile(cachePath(dir, url))
+	if err != nil && os.IsNotExist(err) && Offline {
+		return nil, &OfflineError{URL: url}
+	}
+	return data, err
+}
+
+// store saves the url content to the cache dir; a failure only costs the next offline resolution
+func store(dir string, url string, data []byte) {
+	if err := os.MkdirAll(dir, 0755); err == nil {
+		writeAtomic(cachePath(dir, url), data, 0644)
+	}
+}
+
+// fetchCached returns the CacheDir copy of the url while it is younger than RemoteTTL, otherwise the url revalidated against the ETag and Last-Modified of the copy; the copy is also used when the url cannot be reached, and while Offline only the copy is used
+func fetchCached(o *options, url string) ([]byte, error) {
+	dir := o.cacheDir()
+	if Offline {
+		return cached(dir, url)
+	}
+	info, err := os.Stat(cachePath(dir, url))
+	if err == nil && time.Since(info.ModTime()) < RemoteTTL {
+		return ioutil.ReadFile(cachePath(dir, url))
+	}
+	data, err := revalidate(o, url, false)
+	var unreachable *neturl.Error
+	if errors.As(err, &unreachable) && o.context().Err() == nil && info != nil {
+		return ioutil.ReadFile(cachePath(dir, url))
+	}
+	return data, err
+}
+
+// revalidate returns the body of an HTTP GET of the url with the headers of the options authorized for it, conditional on the ETag and Last-Modified of the CacheDir copy unless forced, and keeps the body, its ETag and Last-Modified in CacheDir; a not modified response renews the copy for RemoteTTL
+func revalidate(o *options, url string, force bool) ([]byte, error) {
+	request, err := http.NewRequestWithContext(o.context(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range o.headers(url) {
+		request.Header[name] = values
+	}
+	dir := o.cacheDir()
+	path := cachePath(dir, url)
+	if _, err := os.Stat(path); err == nil && !force {
+		for _, v := range validators {
+			if value, err := ioutil.ReadFile(path + v.suffix); err == nil {
+				request.Header.Set(v.condition, string(value))
+			}
+		}
+	}
+	response, err := HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		now := time.Now()
+		os.Chtimes(path, now, now)
+		return ioutil.ReadFile(path)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status `%s`", response.Status)
+	}
+	data, err := readRemote(url, response)
+	if err != nil {
+		return nil, err
+	}
+	store(dir, url, data)
+	for _, v := range validators {
+		if value := response.Header.Get(v.header); len(value) > 0 {
+			writeAtomic(path+v.suffix, []byte(value), 0644)
+		} else {
+			os.Remove(path + v.suffix)
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,153 @@
+package configuration_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestOffline(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.Offline = false
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"lorem"}`))
+	}))
+	cachedURL := server.URL + "/cached.json"
+	if _, _, err := configuration.Compose(configuration.URLSource(cachedURL)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	server.Close()
+	configuration.Offline = true
+	c, _, err := configuration.Compose(configuration.URLSource(cachedURL))
+	if err != nil || c.Name != "lorem" {
+		t.Errorf("Expecting cached lorem, got %v %v", err, c)
+	}
+	_, _, err = configuration.Compose(configuration.URLSource(server.URL + "/uncached.json"))
+	if !errors.Is(err, configuration.ErrOffline) {
+		t.Errorf("Expecting ErrOffline, got %v", err)
+	}
+	var offline *configuration.OfflineError
+	if !errors.As(err, &offline) || offline.URL != server.URL+"/uncached.json" {
+		t.Errorf("Expecting OfflineError, got %v", err)
+	}
+}
+
+func TestRemoteTTL(t *testing.T) {
+	cacheDir, ttl := configuration.CacheDir, configuration.RemoteTTL
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.RemoteTTL = ttl
+	}()
+	var requests, revalidated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/shared/tasks.json" {
+			w.Write([]byte(`{"task": [{"name": "docs", "path": {"include": ["*.go"]}}]}`))
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "base", "include": ["tasks.json"]}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"extends": "`+server.URL+`/shared/base.json"}`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(dir); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "base" || c.FindTask("docs") == nil || requests != 2 {
+		t.Fatalf("Expecting base with docs task after 2 requests, got %v %v %v", c.Name, c.Task, requests)
+	}
+	if err := (&configuration.Configuration{}).LoadFrom(dir); err != nil || requests != 2 {
+		t.Errorf("Expecting cached base, got %v after %v requests", err, requests)
+	}
+	configuration.RemoteTTL = 0
+	if err := (&configuration.Configuration{}).LoadFrom(dir); err != nil || revalidated != 1 {
+		t.Errorf("Expecting revalidated base, got %v after %v revalidations", err, revalidated)
+	}
+	configuration.RemoteTTL = time.Hour
+	requests, revalidated = 0, 0
+	if err := c.RefreshRemote(); err != nil || requests != 2 || revalidated != 0 {
+		t.Errorf("Expecting 2 unconditional requests, got %v %v %v", err, requests, revalidated)
+	}
+}
+
+func TestRemoteLastModified(t *testing.T) {
+	cacheDir, ttl := configuration.CacheDir, configuration.RemoteTTL
+	configuration.CacheDir = t.TempDir()
+	configuration.RemoteTTL = 0
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.RemoteTTL = ttl
+	}()
+	modified := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+	var revalidated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == modified {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", modified)
+		w.Write([]byte(`{"name": "base"}`))
+	}))
+	location := server.URL + "/base.json"
+	for i := 0; i < 2; i++ {
+		c := &configuration.Configuration{}
+		if err := c.LoadFrom(location); err != nil || c.Name != "base" {
+			t.Fatalf("Expecting base, got %v %v", err, c.Name)
+		}
+	}
+	if revalidated != 1 {
+		t.Errorf("Expecting 1 revalidation, got %v", revalidated)
+	}
+	server.Close()
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(location); err != nil || c.Name != "base" {
+		t.Errorf("Expecting cached base while unreachable, got %v %v", err, c.Name)
+	}
+	if _, _, err := configuration.Compose(configuration.URLSource(location)); err != nil {
+		t.Errorf("Expecting cached base while unreachable, got %v", err)
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	userDirs := configuration.UserDirs
+	configuration.UserDirs = &configuration.Dirs{Cache: t.TempDir()}
+	defer func() {
+		configuration.UserDirs = userDirs
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"lorem"}`))
+	}))
+	defer server.Close()
+	if _, _, err := configuration.Compose(configuration.URLSource(server.URL + "/user.json")); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if entries, _ := os.ReadDir(configuration.UserDirs.Cache); len(entries) == 0 {
+		t.Errorf("Expecting the copy within the reassigned UserDirs cache, got none")
+	}
+	dir := t.TempDir()
+	if _, _, err := configuration.Compose(configuration.URLSource(server.URL+"/option.json", configuration.WithCacheDir(dir))); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+		t.Errorf("Expecting the copy within the WithCacheDir directory, got none")
+	}
+}
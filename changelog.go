@@ -0,0 +1,248 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Change contains a single mutation made through the mutation APIs of Configuration, with the caller supplied metadata at the time of the change, or a difference reported by Diff
+type Change struct {
+	Time     time.Time         `json:"time"`
+	Action   string            `json:"action"`
+	Element  string            `json:"element"`
+	Name     string            `json:"name"`
+	Pointer  string            `json:"pointer"`
+	Impact   string            `json:"impact,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+const (
+	// ChangeAdded constant for a Change adding an element
+	ChangeAdded = "added"
+	// ChangeRemoved constant for a Change removing an element
+	ChangeRemoved = "removed"
+	// ChangeChanged constant for a Change modifying an element, only reported by Diff
+	ChangeChanged = "changed"
+)
+
+// String returns the Change as a short sentence, such as `added task docs`
+func (c *Change) String() string {
+	return fmt.Sprintf("%s %s %s", c.Action, c.Element, c.Name)
+}
+
+// changelog contains the mutations recorded on Configuration and the metadata attached to the next ones; never encoded
+type changelog struct {
+	changes  []*Change
+	metadata map[string]string
+}
+
+// SetChangeMetadata attaches the metadata, such as who made a change and why, to every Change recorded afterwards
+func (c *Configuration) SetChangeMetadata(metadata map[string]string) {
+	if c == nil {
+		return
+	}
+	c.changelog.metadata = map[string]string{}
+	for key, value := range metadata {
+		c.changelog.metadata[key] = value
+	}
+}
+
+// Changes returns every Change recorded since Configuration was created or ClearChanges was called, in order
+func (c *Configuration) Changes() []*Change {
+	if c == nil {
+		return nil
+	}
+	return append([]*Change(nil), c.changelog.changes...)
+}
+
+// ChangeSummary returns every recorded Change as one sentence suited to a commit message, such as `Added task docs; removed plugin foo.js`
+func (c *Configuration) ChangeSummary() string {
+	if c == nil {
+		return ""
+	}
+	sentences := make([]string, len(c.changelog.changes))
+	for i, change := range c.changelog.changes {
+		sentences[i] = change.String()
+	}
+	summary := strings.Join(sentences, "; ")
+	if len(summary) == 0 {
+		return summary
+	}
+	return strings.ToUpper(summary[:1]) + summary[1:]
+}
+
+// ClearChanges discards every recorded Change, typically once they are written
+func (c *Configuration) ClearChanges() {
+	if c == nil {
+		return
+	}
+	c.changelog.changes = nil
+}
+
+// record appends a Change with the current metadata
+func (c *Configuration) record(action string, element string, name string, p string) {
+	change := &Change{
+		Time:    time.Now(),
+		Action:  action,
+		Element: element,
+		Name:    name,
+		Pointer: p,
+	}
+	if len(c.changelog.metadata) > 0 {
+		change.Metadata = map[string]string{}
+		for key, value := range c.changelog.metadata {
+			change.Metadata[key] = value
+		}
+	}
+	c.changelog.changes = append(c.changelog.changes, change)
+}
+
+// AddTask appends the Task and records the Change; returns an error if a Task of the same name exists
+func (c *Configuration) AddTask(t *Task) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if t == nil {
+		return newError(CodeMissing, "", "task definition is null")
+	}
+	if c.FindTask(t.Name) != nil {
+		return fmt.Errorf("`%s` task is already defined", t.Name)
+	}
+	c.Task = append(c.Task, t)
+	c.record(ChangeAdded, "task", t.Name, pointer("task", len(c.Task)-1))
+	return nil
+}
+
+// RemoveTask removes the named Task and records the Change; returns false if the Task is not found
+func (c *Configuration) RemoveTask(name string) bool {
+	if c == nil {
+		return false
+	}
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if t.Name == name {
+			c.Task = append(c.Task[:i], c.Task[i+1:]...)
+			c.record(ChangeRemoved, "task", name, pointer("task", i))
+			return true
+		}
+	}
+	return false
+}
+
+// AddScript appends the Script and records the Change; returns an error if a Script of the same name exists
+func (c *Configuration) AddScript(s *Script) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if s == nil {
+		return newError(CodeMissing, "", "script definition is null")
+	}
+	if c.FindScript(s.Name) != nil {
+		return fmt.Errorf("`%s` script is already defined", s.Name)
+	}
+	c.Script = append(c.Script, s)
+	c.record(ChangeAdded, "script", s.Name, pointer("script", len(c.Script)-1))
+	return nil
+}
+
+// RemoveScript removes the named Script and records the Change; returns false if the Script is not found
+func (c *Configuration) RemoveScript(name string) bool {
+	if c == nil {
+		return false
+	}
+	if i := indexScript(c.Script, name); i >= 0 {
+		c.Script = append(c.Script[:i], c.Script[i+1:]...)
+		c.record(ChangeRemoved, "script", name, pointer("script", i))
+		return true
+	}
+	return false
+}
+
+// AddFile appends the File and records the Change; returns an error if any of its types is already handled
+func (c *Configuration) AddFile(f *File) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if f == nil {
+		return newError(CodeMissing, "", "file definition is null")
+	}
+	for _, fileType := range f.Type {
+		if c.indexFile(fileType) >= 0 {
+			return fmt.Errorf("`%s` file type is already defined", fileType)
+		}
+	}
+	c.File = append(c.File, f)
+	c.record(ChangeAdded, "file", strings.Join(f.Type, ","), pointer("file", len(c.File)-1))
+	return nil
+}
+
+// RemoveFile removes the File handling the type and records the Change; returns false if the File is not found
+func (c *Configuration) RemoveFile(fileType string) bool {
+	if c == nil {
+		return false
+	}
+	if i := c.indexFile(fileType); i >= 0 {
+		name := strings.Join(c.File[i].Type, ",")
+		c.File = append(c.File[:i], c.File[i+1:]...)
+		c.record(ChangeRemoved, "file", name, pointer("file", i))
+		return true
+	}
+	return false
+}
+
+// AddPlugin appends a Plugin of the path to the File handling the type and records the Change; returns an error if the File is not found
+func (c *Configuration) AddPlugin(fileType string, path string) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	i := c.indexFile(fileType)
+	if i < 0 {
+		return fmt.Errorf("unknown `%s` file type", fileType)
+	}
+	f := c.File[i]
+	if f.Modify == nil {
+		f.Modify = &Modify{}
+	}
+	f.Modify.Plugin = append(f.Modify.Plugin, &Plugin{Path: path})
+	c.record(ChangeAdded, "plugin", path, pointer("file", i, "modify", "plugin", len(f.Modify.Plugin)-1))
+	return nil
+}
+
+// RemovePlugin removes the Plugin of the path from the File handling the type and records the Change; returns false if the Plugin is not found
+func (c *Configuration) RemovePlugin(fileType string, path string) bool {
+	if c == nil {
+		return false
+	}
+	i := c.indexFile(fileType)
+	if i < 0 || c.File[i].Modify == nil {
+		return false
+	}
+	modify := c.File[i].Modify
+	for j, plugin := range modify.Plugin {
+		if plugin == nil {
+			continue
+		}
+		if plugin.Path == path {
+			modify.Plugin = append(modify.Plugin[:j], modify.Plugin[j+1:]...)
+			c.record(ChangeRemoved, "plugin", path, pointer("file", i, "modify", "plugin", j))
+			return true
+		}
+	}
+	return false
+}
+
+// indexFile returns the index of the File declaring the type, or -1
+func (c *Configuration) indexFile(fileType string) int {
+	for i, f := range c.File {
+		if f == nil {
+			continue
+		}
+		if contains(f.Type, fileType) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,54 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Changes(t *testing.T) {
+	c := &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{
+							Path: "foo.js",
+						},
+					},
+				},
+			},
+		},
+	}
+	c.SetChangeMetadata(map[string]string{"author": "lorem"})
+	if err := c.AddTask(&configuration.Task{Name: "docs"}); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if err := c.AddTask(&configuration.Task{Name: "docs"}); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if !c.RemovePlugin("js", "foo.js") || c.RemovePlugin("js", "foo.js") {
+		t.Errorf("Expecting foo.js removed once")
+	}
+	changes := c.Changes()
+	if len(changes) != 2 {
+		t.Fatalf("Expecting 2 changes, got %v", changes)
+	}
+	if changes[0].Pointer != "/task/0" || changes[0].Metadata["author"] != "lorem" || changes[0].Time.IsZero() {
+		t.Errorf("Expecting /task/0 by lorem, got %v", changes[0])
+	}
+	if changes[1].Pointer != "/file/0/modify/plugin/0" {
+		t.Errorf("Expecting /file/0/modify/plugin/0, got %v", changes[1].Pointer)
+	}
+	if summary := c.ChangeSummary(); summary != "Added task docs; removed plugin foo.js" {
+		t.Errorf("Expecting Added task docs; removed plugin foo.js, got %v", summary)
+	}
+	c.ClearChanges()
+	if len(c.Changes()) != 0 || c.ChangeSummary() != "" {
+		t.Errorf("Expecting no changes, got %v", c.Changes())
+	}
+	if err := c.AddPlugin("go", "bar.js"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
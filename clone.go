@@ -0,0 +1,245 @@
+package configuration
+
+import "github.com/emits-io/core"
+
+// Clone returns a deep copy of Configuration sharing no pointer, slice or map with it, including its recorded changes; nil returns nil
+func (c *Configuration) Clone() *Configuration {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	clone.Include = copyStrings(c.Include)
+	clone.Task = cloneTasks(c.Task)
+	clone.Script = cloneScripts(c.Script)
+	clone.File = cloneFiles(c.File)
+	clone.Definitions = c.Definitions.Clone()
+	clone.Requires = c.Requires.Clone()
+	clone.Var = copyVars(c.Var)
+	if c.Profile != nil {
+		clone.Profile = map[string]*Profile{}
+		for name, profile := range c.Profile {
+			clone.Profile[name] = profile.Clone()
+		}
+	}
+	if c.Projects != nil {
+		clone.Projects = map[string]*Configuration{}
+		for name, project := range c.Projects {
+			clone.Projects[name] = project.Clone()
+		}
+	}
+	clone.changelog.changes = nil
+	for _, change := range c.changelog.changes {
+		copied := *change
+		copied.Metadata = copyVars(change.Metadata)
+		clone.changelog.changes = append(clone.changelog.changes, &copied)
+	}
+	clone.changelog.metadata = copyVars(c.changelog.metadata)
+	return &clone
+}
+
+// Clone returns a deep copy of Task; nil returns nil
+func (t *Task) Clone() *Task {
+	if t == nil {
+		return nil
+	}
+	task := *t
+	task.Path = t.Path.Clone()
+	task.Param = copyParams(t.Param)
+	task.Inputs = copyStrings(t.Inputs)
+	task.Outputs = copyStrings(t.Outputs)
+	return &task
+}
+
+// Clone returns a deep copy of Path; nil returns nil
+func (p *Path) Clone() *Path {
+	if p == nil {
+		return nil
+	}
+	path := *p
+	path.Include = copyStrings(p.Include)
+	path.Exclude = copyStrings(p.Exclude)
+	path.Hidden = copyBool(p.Hidden)
+	return &path
+}
+
+// copyBool returns a copy of the optional bool; nil returns nil
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	value := *b
+	return &value
+}
+
+// Clone returns a deep copy of Script; nil returns nil
+func (s *Script) Clone() *Script {
+	if s == nil {
+		return nil
+	}
+	script := *s
+	script.Task = copyStrings(s.Task)
+	script.Param = copyParams(s.Param)
+	return &script
+}
+
+// Clone returns a deep copy of File; nil returns nil
+func (f *File) Clone() *File {
+	if f == nil {
+		return nil
+	}
+	file := *f
+	file.Type = copyStrings(f.Type)
+	file.Parse = f.Parse.Clone()
+	file.Modify = f.Modify.Clone()
+	if f.Audit != nil {
+		file.Audit = make([]*Audit, 0, len(f.Audit))
+		for _, a := range f.Audit {
+			file.Audit = append(file.Audit, a.Clone())
+		}
+	}
+	return &file
+}
+
+// Clone returns a deep copy of Parse; nil returns nil
+func (p *Parse) Clone() *Parse {
+	if p == nil {
+		return nil
+	}
+	parse := *p
+	parse.Exclude = copyStrings(p.Exclude)
+	if p.Comment != nil {
+		comment := *p.Comment
+		if p.Comment.Block != nil {
+			block := *p.Comment.Block
+			comment.Block = &block
+		}
+		parse.Comment = &comment
+	}
+	return &parse
+}
+
+// Clone returns a deep copy of Modify; compiled regular expressions are shared as they are safe for concurrent use; nil returns nil
+func (m *Modify) Clone() *Modify {
+	if m == nil {
+		return nil
+	}
+	modify := *m
+	if m.Plugin != nil {
+		modify.Plugin = make([]*Plugin, 0, len(m.Plugin))
+		for _, p := range m.Plugin {
+			if p == nil {
+				modify.Plugin = append(modify.Plugin, nil)
+				continue
+			}
+			plugin := *p
+			modify.Plugin = append(modify.Plugin, &plugin)
+		}
+	}
+	if m.Regex != nil {
+		modify.Regex = make([]*core.RegularExpression, 0, len(m.Regex))
+		for _, r := range m.Regex {
+			if r == nil {
+				modify.Regex = append(modify.Regex, nil)
+				continue
+			}
+			regex := *r
+			modify.Regex = append(modify.Regex, &regex)
+		}
+	}
+	return &modify
+}
+
+// Clone returns a deep copy of Audit; nil returns nil
+func (a *Audit) Clone() *Audit {
+	if a == nil {
+		return nil
+	}
+	return &Audit{
+		Path:    a.Path,
+		Include: copyStrings(a.Include),
+		Exclude: copyStrings(a.Exclude),
+	}
+}
+
+// Clone returns a deep copy of Definitions; nil returns nil
+func (d *Definitions) Clone() *Definitions {
+	if d == nil {
+		return nil
+	}
+	return &Definitions{
+		Task: cloneTasks(d.Task),
+		File: cloneFiles(d.File),
+	}
+}
+
+// Clone returns a deep copy of Requires; nil returns nil
+func (r *Requires) Clone() *Requires {
+	if r == nil {
+		return nil
+	}
+	return &Requires{
+		Env:      copyStrings(r.Env),
+		Commands: copyStrings(r.Commands),
+		Files:    copyStrings(r.Files),
+	}
+}
+
+// Clone returns a deep copy of Profile; nil returns nil
+func (p *Profile) Clone() *Profile {
+	if p == nil {
+		return nil
+	}
+	return &Profile{
+		Task:   cloneTasks(p.Task),
+		Script: cloneScripts(p.Script),
+		File:   cloneFiles(p.File),
+	}
+}
+
+// cloneTasks returns a deep copy of the tasks, preserving nil
+func cloneTasks(tasks []*Task) []*Task {
+	if tasks == nil {
+		return nil
+	}
+	cloned := make([]*Task, 0, len(tasks))
+	for _, t := range tasks {
+		cloned = append(cloned, t.Clone())
+	}
+	return cloned
+}
+
+// cloneScripts returns a deep copy of the scripts, preserving nil
+func cloneScripts(scripts []*Script) []*Script {
+	if scripts == nil {
+		return nil
+	}
+	cloned := make([]*Script, 0, len(scripts))
+	for _, s := range scripts {
+		cloned = append(cloned, s.Clone())
+	}
+	return cloned
+}
+
+// cloneFiles returns a deep copy of the files, preserving nil
+func cloneFiles(files []*File) []*File {
+	if files == nil {
+		return nil
+	}
+	cloned := make([]*File, 0, len(files))
+	for _, f := range files {
+		cloned = append(cloned, f.Clone())
+	}
+	return cloned
+}
+
+// copyVars returns a copy of the map, preserving nil
+func copyVars(vars map[string]string) map[string]string {
+	if vars == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(vars))
+	for key, value := range vars {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,56 @@
+package configuration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_Clone(t *testing.T) {
+	c := validConfiguration()
+	c.Task[0].Param = []*configuration.Param{{Name: "out"}}
+	c.Script = []*configuration.Script{{Name: "all", Task: []string{"lorem"}}}
+	c.File[0].Parse.Comment.Block = &core.CommentBlock{Start: "/*", End: "*/"}
+	c.File[0].Modify = &configuration.Modify{
+		Plugin: []*configuration.Plugin{{Path: "a.js"}},
+		Regex:  []*core.RegularExpression{{Find: "a", Replace: "b"}},
+	}
+	c.File[0].Audit = []*configuration.Audit{{Path: "audit.js", Include: []string{"*.go"}}}
+	c.Definitions = &configuration.Definitions{Task: []*configuration.Task{{Name: "base"}}}
+	c.Requires = &configuration.Requires{Env: []string{"HOME"}}
+	c.Var = map[string]string{"src": "src"}
+	c.Profile = map[string]*configuration.Profile{"ci": {Task: []*configuration.Task{{Name: "lorem"}}}}
+	c.Projects = map[string]*configuration.Configuration{"web": validConfiguration()}
+	clone := c.Clone()
+	if !reflect.DeepEqual(c, clone) {
+		t.Fatalf("Expecting %+v, got %+v", c, clone)
+	}
+	clone.Task[0].Path.Include[0] = "*.md"
+	clone.Task[0].Param[0].Name = "in"
+	clone.Script[0].Task[0] = "ipsum"
+	clone.File[0].Type[0] = "md"
+	clone.File[0].Parse.Comment.Block.Start = "<!--"
+	clone.File[0].Modify.Plugin[0].Path = "b.js"
+	clone.File[0].Modify.Regex[0].Find = "c"
+	clone.File[0].Audit[0].Include[0] = "*.md"
+	clone.Definitions.Task[0].Name = "other"
+	clone.Requires.Env[0] = "PATH"
+	clone.Var["src"] = "lib"
+	clone.Profile["ci"].Task[0].Name = "ipsum"
+	clone.Projects["web"].Task[0].Name = "ipsum"
+	if reflect.DeepEqual(c, clone) {
+		t.Errorf("Expecting independent clone, got equal")
+	}
+	if !reflect.DeepEqual(c.Projects["web"], validConfiguration()) || c.Task[0].Path.Include[0] != "*.go" || c.Task[0].Param[0].Name != "out" ||
+		c.Script[0].Task[0] != "lorem" || c.File[0].Type[0] != "go" || c.File[0].Parse.Comment.Block.Start != "/*" ||
+		c.File[0].Modify.Plugin[0].Path != "a.js" || c.File[0].Modify.Regex[0].Find != "a" || c.File[0].Audit[0].Include[0] != "*.go" ||
+		c.Definitions.Task[0].Name != "base" || c.Requires.Env[0] != "HOME" || c.Var["src"] != "src" || c.Profile["ci"].Task[0].Name != "lorem" {
+		t.Errorf("Expecting original unchanged, got %+v", c)
+	}
+	var empty *configuration.Configuration
+	if empty.Clone() != nil {
+		t.Errorf("Expecting nil, got non-nil")
+	}
+}
@@ -0,0 +1,141 @@
+package configuration
+
+import (
+	"encoding/json"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Codec encodes and decodes Configuration in a single format; implementations must honour the `json` struct tags of Configuration
+type Codec interface {
+	// Name returns the unique name of the format, used as its Format
+	Name() string
+	// Extensions returns the file extensions of the format without the leading `.`
+	Extensions() []string
+	// Marshal returns v encoded in the format
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes data in the format into v
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Evaluator is implemented by a Codec whose documents are programs evaluating to JSON, such as Jsonnet; loading evaluates a document once and checks and decodes the resulting JSON
+type Evaluator interface {
+	// Evaluate returns the JSON the document evaluates to; relative imports resolve against dir, the directory of the document, or the working directory when empty
+	Evaluate(data []byte, dir string) ([]byte, error)
+}
+
+// codecs contains every registered Codec keyed by name
+var codecs = struct {
+	sync.RWMutex
+	byName map[string]Codec
+}{
+	byName: map[string]Codec{},
+}
+
+func init() {
+	RegisterCodec(jsonCodec{})
+}
+
+// RegisterCodec adds the Codec to the registry, replacing any Codec of the same name; external packages call it from init to add formats
+func RegisterCodec(codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.byName[codec.Name()] = codec
+}
+
+// FindCodec returns the registered Codec of the Format, or nil if not registered
+func FindCodec(format Format) Codec {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	return codecs.byName[string(format)]
+}
+
+// Codecs returns every registered Codec ordered by name
+func Codecs() []Codec {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	var list []Codec
+	for _, codec := range codecs.byName {
+		list = append(list, codec)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
+// codecFor returns the registered Codec for the extension of path, falling back to JSON when no Codec claims it
+func codecFor(path string) Codec {
+	extension := strings.TrimPrefix(filepath.Ext(path), ".")
+	for _, codec := range Codecs() {
+		if contains(codec.Extensions(), extension) {
+			return codec
+		}
+	}
+	return FindCodec(JSON)
+}
+
+// AlternateConfigFiles lists the other file names Load recognizes, in order of precedence after ConfigFile and ConfigFile with the extension of a registered Codec
+var AlternateConfigFiles = []string{"emits.config.json", ".emitsrc.json", ".emitsrc"}
+
+// ConfigFileFS returns the first file present in the file system root in order of precedence: ConfigFile, ConfigFile with the extension of a registered Codec, then AlternateConfigFiles; ConfigFile is returned when none is present
+func ConfigFileFS(fsys fs.FS) string {
+	if files := configFiles(fsys); len(files) > 0 {
+		return files[0]
+	}
+	return ConfigFile
+}
+
+// configFiles returns every configuration file present in the file system root, in the order of precedence of ConfigFileFS
+func configFiles(fsys fs.FS) []string {
+	candidates := []string{ConfigFile}
+	base := strings.TrimSuffix(ConfigFile, filepath.Ext(ConfigFile))
+	for _, codec := range Codecs() {
+		for _, extension := range codec.Extensions() {
+			if !contains(candidates, base+"."+extension) {
+				candidates = append(candidates, base+"."+extension)
+			}
+		}
+	}
+	var present []string
+	for _, name := range append(candidates, AlternateConfigFiles...) {
+		if info, err := fs.Stat(fsys, name); err == nil && !info.IsDir() {
+			present = append(present, name)
+		}
+	}
+	return present
+}
+
+// formatFile returns the file name Write uses for the Codec: ConfigFile with the first Codec extension
+func formatFile(codec Codec) string {
+	if contains(codec.Extensions(), strings.TrimPrefix(filepath.Ext(ConfigFile), ".")) {
+		return ConfigFile
+	}
+	return strings.TrimSuffix(ConfigFile, filepath.Ext(ConfigFile)) + "." + codec.Extensions()[0]
+}
+
+// jsonCodec is the built-in Codec of ConfigFile
+type jsonCodec struct{}
+
+// Name returns the JSON Format
+func (jsonCodec) Name() string {
+	return string(JSON)
+}
+
+// Extensions returns the JSON file extensions
+func (jsonCodec) Extensions() []string {
+	return []string{"json"}
+}
+
+// Marshal returns v encoded as tab indented JSON
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "\t")
+}
+
+// Unmarshal decodes JSON data into v
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
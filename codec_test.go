@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+// compactCodec is a Codec of single line JSON documents
+type compactCodec struct{}
+
+func (compactCodec) Name() string {
+	return "compact"
+}
+
+func (compactCodec) Extensions() []string {
+	return []string{"cjson"}
+}
+
+func (compactCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (compactCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	configuration.RegisterCodec(compactCodec{})
+	if configuration.FindCodec("compact") == nil {
+		t.Fatalf("Expecting codec, got nil")
+	}
+	out, err := configuration.Convert([]byte(document), configuration.JSON, "compact")
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if strings.Contains(string(out), "\n") {
+		t.Errorf("Expecting single line, got %s", out)
+	}
+	path := filepath.Join(t.TempDir(), "emits.cjson")
+	os.WriteFile(path, out, 0644)
+	report := configuration.ValidateAll([]string{path})[path]
+	if report.LoadError != nil {
+		t.Errorf("Expecting nil, got %v", report.LoadError)
+	}
+	if configuration.FindCodec(configuration.JSON) == nil {
+		t.Errorf("Expecting built-in json codec, got nil")
+	}
+}
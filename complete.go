@@ -0,0 +1,17 @@
+package configuration
+
+// Complete returns an error for every required section still missing, such as tasks, files, names, paths and parse comments; the Configuration is left untouched, so a partially built Configuration may be checked as it grows
+func (c *Configuration) Complete() []error {
+	var missing []error
+	for _, err := range c.Clone().Validate() {
+		if ErrorCode(err) == CodeMissing {
+			missing = append(missing, err)
+		}
+	}
+	return missing
+}
+
+// nullConfiguration returns the error of a method called on a nil Configuration
+func nullConfiguration() *ValidationError {
+	return newError(CodeMissing, "", "`%s` configuration is null", ConfigFile)
+}
@@ -0,0 +1,144 @@
+package configuration_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_Complete(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{{Name: "docs"}},
+		File: []*configuration.File{{Type: []string{"go"}}},
+	}
+	var pointers []string
+	for _, err := range c.Complete() {
+		if !errors.Is(err, configuration.ErrMissing) {
+			t.Errorf("Expecting ErrMissing, got %v", err)
+		}
+		pointers = append(pointers, configuration.Pointer(err))
+	}
+	expected := []string{"/task/0/path", "/file/0/parse"}
+	if len(pointers) != len(expected) || pointers[0] != expected[0] || pointers[1] != expected[1] {
+		t.Errorf("Expecting %v, got %v", expected, pointers)
+	}
+	if c.Task[0].Path != nil || c.File[0].Parse != nil {
+		t.Errorf("Expecting untouched configuration, got %v %v", c.Task[0], c.File[0])
+	}
+	c.Task[0].Path = &configuration.Path{Include: []string{"*.go"}}
+	c.File[0].Parse = &configuration.Parse{Comment: &core.Comment{Line: "//"}}
+	if missing := c.Complete(); len(missing) != 0 {
+		t.Errorf("Expecting complete, got %v", missing)
+	}
+}
+
+func TestConfiguration_Nil(t *testing.T) {
+	var c *configuration.Configuration
+	if c.FindTask("docs") != nil || c.FindScript("docs") != nil || c.FindFile("go") != nil || c.Lint() != nil {
+		t.Errorf("Expecting nil lookups on nil configuration")
+	}
+	if errs := c.Validate(); len(errs) != 1 || configuration.ErrorCode(errs[0]) != configuration.CodeMissing {
+		t.Errorf("Expecting missing configuration, got %v", errs)
+	}
+	if report := c.Report(); len(report.Errors) != 1 {
+		t.Errorf("Expecting 1 error, got %v", report.Errors)
+	}
+	if errs := (*configuration.Task)(nil).Validate(); len(errs) != 1 {
+		t.Errorf("Expecting missing task, got %v", errs)
+	}
+	if errs := (*configuration.File)(nil).Validate(); len(errs) != 1 {
+		t.Errorf("Expecting missing file, got %v", errs)
+	}
+	if errs := (&configuration.Script{Name: "all", Task: []string{"docs"}}).Validate(nil); len(errs) != 1 || configuration.ErrorCode(errs[0]) != configuration.CodeUnknown {
+		t.Errorf("Expecting unknown task, got %v", errs)
+	}
+	if errs := (*configuration.Parse)(nil).Validate(nil); len(errs) != 1 {
+		t.Errorf("Expecting missing parse, got %v", errs)
+	}
+}
+
+func TestConfiguration_Partial(t *testing.T) {
+	c := &configuration.Configuration{
+		Task:   []*configuration.Task{nil, {Name: "docs", Path: &configuration.Path{Include: []string{"*.go"}}, Param: []*configuration.Param{nil}}},
+		File:   []*configuration.File{nil, {Type: []string{"go"}, Parse: &configuration.Parse{Comment: &core.Comment{Line: "//"}}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{nil}, Regex: []*core.RegularExpression{nil}}}},
+		Script: []*configuration.Script{nil, {Name: "all", Task: []string{"docs"}, Param: []*configuration.Param{nil}}},
+		Definitions: &configuration.Definitions{
+			Task: []*configuration.Task{nil},
+			File: []*configuration.File{nil},
+		},
+		Profile:  map[string]*configuration.Profile{"ci": nil},
+		Projects: map[string]*configuration.Configuration{"web": nil},
+	}
+	expected := []string{"/task/0", "/task/1/param/0", "/definitions/task/0", "/definitions/file/0", "/file/0", "/script/0", "/script/1/param/0"}
+	var pointers []string
+	for _, err := range c.Validate() {
+		pointers = append(pointers, configuration.Pointer(err))
+	}
+	if len(pointers) != len(expected) {
+		t.Fatalf("Expecting %v, got %v", expected, pointers)
+	}
+	for i := range expected {
+		if pointers[i] != expected[i] {
+			t.Errorf("Expecting %v, got %v", expected[i], pointers[i])
+		}
+	}
+	c.Lint()
+	c.Report()
+	c.Clone()
+	c.Equal(c)
+	c.Normalize()
+	c.Diff(&configuration.Configuration{})
+	c.TaskDependencies()
+	c.ResolveVars()
+}
+
+func TestNilReceivers(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	receivers := []interface{}{
+		(*configuration.Configuration)(nil),
+		(*configuration.Task)(nil),
+		(*configuration.Script)(nil),
+		(*configuration.File)(nil),
+		(*configuration.Report)(nil),
+		(*configuration.Path)(nil),
+		(*configuration.Parse)(nil),
+		(*configuration.Modify)(nil),
+	}
+	for _, receiver := range receivers {
+		value := reflect.ValueOf(receiver)
+		for i := 0; i < value.NumMethod(); i++ {
+			method, name := value.Method(i), value.Type().Method(i).Name
+			var arguments []reflect.Value
+			callable := true
+			for j := 0; j < method.Type().NumIn(); j++ {
+				in := method.Type().In(j)
+				if method.Type().IsVariadic() && j == method.Type().NumIn()-1 {
+					break
+				}
+				callable = callable && in.Kind() != reflect.Func
+				arguments = append(arguments, reflect.Zero(in))
+			}
+			if !callable {
+				continue
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("Expecting nil %T receiver of %s tolerated, got %v", receiver, name, r)
+					}
+				}()
+				method.Call(arguments)
+			}()
+		}
+	}
+	c := &configuration.Configuration{}
+	if c.AddTask(nil) == nil || c.AddScript(nil) == nil || c.AddFile(nil) == nil {
+		t.Errorf("Expecting errors adding nil definitions")
+	}
+}
@@ -0,0 +1,205 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CompletionKey constant for a Completion suggesting an object key
+	CompletionKey = "key"
+	// CompletionValue constant for a Completion suggesting an enumerated value
+	CompletionValue = "value"
+	// CompletionTask constant for a Completion suggesting a task name
+	CompletionTask = "task"
+	// CompletionFile constant for a Completion suggesting a definition file name
+	CompletionFile = "file"
+	// CompletionFileType constant for a Completion suggesting a file type
+	CompletionFileType = "type"
+	// CompletionParam constant for a Completion suggesting a param name
+	CompletionParam = "param"
+)
+
+// Completion contains a single suggestion for a document position
+type Completion struct {
+	Label  string `json:"label"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Completions returns the suggestions valid at the JSON pointer starting with prefix; a pointer to an object suggests its keys, a pointer to a value suggests values
+func (c *Configuration) Completions(path string, prefix string) []*Completion {
+	var completions []*Completion
+	add := func(label string, kind string, detail string) {
+		if !strings.HasPrefix(label, prefix) {
+			return
+		}
+		for _, completion := range completions {
+			if completion.Label == label {
+				return
+			}
+		}
+		completions = append(completions, &Completion{
+			Label:  label,
+			Kind:   kind,
+			Detail: detail,
+		})
+	}
+	t := typeAt(path)
+	if t == nil {
+		return completions
+	}
+	parts := tokens(path)
+	switch {
+	case t.Kind() == reflect.Struct:
+		names, fields := jsonFields(t)
+		for _, name := range names {
+			add(name, CompletionKey, indirect(fields[name].Type).Kind().String())
+		}
+	case t.Kind() == reflect.Bool:
+		add("true", CompletionValue, "")
+		add("false", CompletionValue, "")
+	case match("script/*/task/*", strings.Join(parts, "/")):
+		for _, task := range c.Task {
+			if task == nil {
+				continue
+			}
+			add(task.Name, CompletionTask, "")
+		}
+	case match("**/task/*/extends", strings.Join(parts, "/")):
+		for _, task := range c.Task {
+			if task == nil {
+				continue
+			}
+			add(task.Name, CompletionTask, "")
+		}
+		if c.Definitions != nil {
+			for _, task := range c.Definitions.Task {
+				if task == nil {
+					continue
+				}
+				add(task.Name, CompletionTask, "definition")
+			}
+		}
+	case match("**/file/*/extends", strings.Join(parts, "/")):
+		if c.Definitions != nil {
+			for _, file := range c.Definitions.File {
+				if file == nil {
+					continue
+				}
+				add(file.Name, CompletionFile, "definition")
+			}
+		}
+	case match("**/file/*/type/*", strings.Join(parts, "/")):
+		for _, file := range c.File {
+			if file == nil {
+				continue
+			}
+			for _, fileType := range file.Type {
+				add(fileType, CompletionFileType, "")
+			}
+		}
+		for _, l := range languages {
+			for _, extension := range l.extension {
+				add(extension, CompletionFileType, l.name)
+			}
+		}
+	case match("script/*/param/*/name", strings.Join(parts, "/")):
+		index, _ := strconv.Atoi(parts[1])
+		if index < len(c.Script) {
+			for _, name := range c.Script[index].Task {
+				if task := c.FindTask(name); task != nil {
+					for _, param := range c.resolve(task).Param {
+						add(param.Name, CompletionParam, task.Name)
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(completions, func(i, j int) bool {
+		return completions[i].Label < completions[j].Label
+	})
+	return completions
+}
+
+// completionWord matches a name that needs no quoting within a shell completion snippet
+var completionWord = regexp.MustCompile(`^[A-Za-z0-9._:/@+-]+$`)
+
+// CompletionNames contains the names a command line may complete against Configuration, each sorted
+type CompletionNames struct {
+	Scripts []string `json:"scripts"`
+	Tasks   []string `json:"tasks"`
+	Types   []string `json:"types"`
+}
+
+// CompletionData returns the names of every enabled script, task and file type of Configuration as stable, indented JSON
+func (c *Configuration) CompletionData() ([]byte, error) {
+	return json.MarshalIndent(c.completionNames(), "", "\t")
+}
+
+// CompletionScript returns a bash, zsh or fish snippet completing `emits run <tab>` with the script and task names of Configuration; names that would need shell quoting are left out
+func (c *Configuration) CompletionScript(shell string) (string, error) {
+	names := c.completionNames()
+	var words []string
+	for _, name := range append(names.Scripts, names.Tasks...) {
+		if completionWord.MatchString(name) && !contains(words, name) {
+			words = append(words, name)
+		}
+	}
+	list := strings.Join(words, " ")
+	switch shell {
+	case "bash":
+		return "_emits() {\n\tif [ \"$COMP_CWORD\" -eq 2 ] && [ \"${COMP_WORDS[1]}\" = run ]; then\n\t\tCOMPREPLY=($(compgen -W \"" + list + "\" -- \"${COMP_WORDS[COMP_CWORD]}\"))\n\tfi\n}\ncomplete -F _emits emits\n", nil
+	case "zsh":
+		return "#compdef emits\n_emits() {\n\tif (( CURRENT == 3 )) && [[ ${words[2]} == run ]]; then\n\t\tcompadd -- " + list + "\n\tfi\n}\ncompdef _emits emits\n", nil
+	case "fish":
+		return "complete -c emits -n '__fish_seen_subcommand_from run' -f -a '" + list + "'\n", nil
+	}
+	return "", fmt.Errorf("unsupported `%s` shell", shell)
+}
+
+// completionNames returns the sorted, unique names of every enabled script, task and file type
+func (c *Configuration) completionNames() *CompletionNames {
+	names := &CompletionNames{
+		Scripts: []string{},
+		Tasks:   []string{},
+		Types:   []string{},
+	}
+	if c == nil {
+		return names
+	}
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if !s.Disabled && len(s.Name) > 0 {
+			names.Scripts = append(names.Scripts, s.Name)
+		}
+	}
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if !c.resolve(t).Disabled && len(t.Name) > 0 {
+			names.Tasks = append(names.Tasks, t.Name)
+		}
+	}
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		if file := c.resolveFile(f); !file.Disabled {
+			names.Types = append(names.Types, file.Type...)
+		}
+	}
+	for _, list := range []*[]string{&names.Scripts, &names.Tasks, &names.Types} {
+		sort.Strings(*list)
+		*list = dedupe(*list)
+	}
+	return names
+}
@@ -0,0 +1,77 @@
+package configuration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func labels(completions []*configuration.Completion) []string {
+	var l []string
+	for _, c := range completions {
+		l = append(l, c.Label)
+	}
+	return l
+}
+
+func TestConfiguration_Completions(t *testing.T) {
+	c := paramConfiguration()
+	l := labels(c.Completions("/task/0", "pa"))
+	if len(l) != 2 || l[0] != "param" || l[1] != "path" {
+		t.Errorf("Expecting param and path, got %v", l)
+	}
+	l = labels(c.Completions("/script/0/task/0", ""))
+	if len(l) != 2 || l[0] != "base" || l[1] != "docs" {
+		t.Errorf("Expecting base and docs, got %v", l)
+	}
+	l = labels(c.Completions("/file/0/type/0", "t"))
+	if len(l) != 2 || l[0] != "ts" || l[1] != "tsx" {
+		t.Errorf("Expecting ts and tsx, got %v", l)
+	}
+	l = labels(c.Completions("/file/0/parse/source", ""))
+	if len(l) != 2 {
+		t.Errorf("Expecting true and false, got %v", l)
+	}
+	l = labels(c.Completions("/script/0/param/0/name", ""))
+	if len(l) != 2 || l[0] != "format" || l[1] != "out" {
+		t.Errorf("Expecting format and out, got %v", l)
+	}
+	l = labels(c.Completions("/file/0/modify/regex/0", ""))
+	if len(l) != 2 || l[0] != "find" || l[1] != "replace" {
+		t.Errorf("Expecting find and replace, got %v", l)
+	}
+	l = labels(c.Completions("/unknown", ""))
+	if l != nil {
+		t.Errorf("Expecting nil, got %v", l)
+	}
+}
+
+func TestConfiguration_CompletionData(t *testing.T) {
+	c := &configuration.Configuration{
+		Task:   []*configuration.Task{{Name: "lint"}, {Name: "docs"}, {Name: "old", Disabled: true}},
+		Script: []*configuration.Script{{Name: "all"}, {Name: "my script"}},
+		File:   []*configuration.File{{Type: []string{"ts", "go"}}, {Type: []string{"go"}}},
+	}
+	data, err := c.CompletionData()
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	expected := "{\n\t\"scripts\": [\n\t\t\"all\",\n\t\t\"my script\"\n\t],\n\t\"tasks\": [\n\t\t\"docs\",\n\t\t\"lint\"\n\t],\n\t\"types\": [\n\t\t\"go\",\n\t\t\"ts\"\n\t]\n}"
+	if string(data) != expected {
+		t.Errorf("Expecting %v, got %v", expected, string(data))
+	}
+	fish, err := c.CompletionScript("fish")
+	if err != nil || fish != "complete -c emits -n '__fish_seen_subcommand_from run' -f -a 'all docs lint'\n" {
+		t.Errorf("Expecting fish snippet, got %v %v", fish, err)
+	}
+	for _, shell := range []string{"bash", "zsh"} {
+		snippet, err := c.CompletionScript(shell)
+		if err != nil || !strings.Contains(snippet, "all docs lint") {
+			t.Errorf("Expecting %v snippet, got %v %v", shell, snippet, err)
+		}
+	}
+	if _, err := c.CompletionScript("powershell"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
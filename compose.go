@@ -0,0 +1,319 @@
+package configuration
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Source provides a single Configuration layer to Compose
+type Source interface {
+	// Name returns a human readable description of the Source, used in errors and Report
+	Name() string
+	// Configuration returns the decoded layer
+	Configuration() (*Configuration, error)
+}
+
+// fileSource is a Source read from a file, decoded with the Codec of its extension
+type fileSource struct {
+	path string
+}
+
+// FileSource returns a Source read from the file at path
+func FileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+// Name returns the path of the Source
+func (s *fileSource) Name() string {
+	return s.path
+}
+
+// Configuration returns the file decoded with the Codec of its extension
+func (s *fileSource) Configuration() (*Configuration, error) {
+	c := &Configuration{}
+	if err := c.load(s.path); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// fsSource is a Source read from a file of a file system, decoded with the Codec of its extension
+type fsSource struct {
+	fsys fs.FS
+	name string
+}
+
+// FSSource returns a Source read from the named file of the file system, such as an in-memory fstest.MapFS
+func FSSource(fsys fs.FS, name string) Source {
+	return &fsSource{fsys: fsys, name: name}
+}
+
+// Name returns the file name of the Source
+func (s *fsSource) Name() string {
+	return s.name
+}
+
+// Configuration returns the file decoded with the Codec of its extension
+func (s *fsSource) Configuration() (*Configuration, error) {
+	data, err := fs.ReadFile(s.fsys, s.name)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data, Format(codecFor(s.name).Name()))
+}
+
+// readerSource is a Source read once from an io.Reader
+type readerSource struct {
+	name   string
+	reader io.Reader
+	format Format
+}
+
+// ReaderSource returns a Source read from the reader, such as os.Stdin, decoded with the Codec of format
+func ReaderSource(name string, reader io.Reader, format Format) Source {
+	return &readerSource{name: name, reader: reader, format: format}
+}
+
+// Name returns the name of the Source
+func (s *readerSource) Name() string {
+	return s.name
+}
+
+// Configuration returns the reader decoded with the Codec of the Source format
+func (s *readerSource) Configuration() (*Configuration, error) {
+	data, err := ioutil.ReadAll(s.reader)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data, s.format)
+}
+
+// envSource is a Source read from an environment variable
+type envSource struct {
+	variable string
+	format   Format
+}
+
+// EnvSource returns a Source read from the document held by the environment variable; an unset variable is an empty layer
+func EnvSource(variable string, format Format) Source {
+	return &envSource{variable: variable, format: format}
+}
+
+// Name returns the environment variable of the Source
+func (s *envSource) Name() string {
+	return "$" + s.variable
+}
+
+// Configuration returns the environment variable decoded with the Codec of the Source format
+func (s *envSource) Configuration() (*Configuration, error) {
+	value, ok := os.LookupEnv(s.variable)
+	if !ok || len(strings.TrimSpace(value)) == 0 {
+		return &Configuration{}, nil
+	}
+	return decode([]byte(value), s.format)
+}
+
+// urlSource is a Source fetched over HTTP
+type urlSource struct {
+	url     string
+	options []Option
+}
+
+// URLSource returns a Source fetched with an HTTP GET of the url using HTTPClient and cached like a remote extends, decoded with the Codec of its path extension; options may authenticate the request
+func URLSource(url string, options ...Option) Source {
+	return &urlSource{url: url, options: options}
+}
+
+// Name returns the url of the Source
+func (s *urlSource) Name() string {
+	return s.url
+}
+
+// Configuration returns the fetched document decoded with the Codec of the url path extension
+func (s *urlSource) Configuration() (*Configuration, error) {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fetchCached(newOptions(append(s.options[:len(s.options):len(s.options)], withAuthLocation(s.url))), s.url)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data, Format(codecFor(u.Path).Name()))
+}
+
+// decode returns the data decoded into Configuration with the Codec of format
+func decode(data []byte, format Format) (*Configuration, error) {
+	codec := FindCodec(format)
+	if codec == nil {
+		return nil, fmt.Errorf("unsupported format `%s`", format)
+	}
+	c := &Configuration{}
+	if err := codec.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Compose loads every Source concurrently, merges each over the previous layers in order, resolves var references and interpolates environment variable references of the result like LoadFrom, and returns it with its validation Report; load failures are returned as SourceErrors
+func Compose(sources ...Source) (*Configuration, *Report, error) {
+	layers, err := loadSources(sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &Configuration{}
+	var names []string
+	for i, layer := range layers {
+		c.Merge(layer)
+		names = append(names, sources[i].Name())
+	}
+	if err := c.prepare(); err != nil {
+		return nil, nil, err
+	}
+	report := c.Report()
+	report.Path = strings.Join(names, ", ")
+	return c, report, nil
+}
+
+// Merge applies the layer over Configuration; non-empty scalars replace, tasks, scripts and files replace those of the same name (files without a name by type) and are otherwise appended, requirements accumulate and vars, profiles and projects replace those of the same name
+func (c *Configuration) Merge(layer *Configuration) {
+	if c == nil || layer == nil {
+		return
+	}
+	if layer.SchemaVersion != 0 {
+		c.SchemaVersion = layer.SchemaVersion
+	}
+	mergeString(&c.Schema, layer.Schema)
+	mergeString(&c.Name, layer.Name)
+	mergeString(&c.Description, layer.Description)
+	mergeString(&c.Author, layer.Author)
+	mergeString(&c.License, layer.License)
+	mergeString(&c.Version, layer.Version)
+	c.Task = mergeTasks(c.Task, layer.Task)
+	for _, script := range layer.Script {
+		if script == nil {
+			continue
+		}
+		if i := indexScript(c.Script, script.Name); i >= 0 {
+			c.Script[i] = script
+		} else {
+			c.Script = append(c.Script, script)
+		}
+	}
+	c.File = mergeFiles(c.File, layer.File)
+	for name, value := range layer.Var {
+		if c.Var == nil {
+			c.Var = map[string]string{}
+		}
+		c.Var[name] = value
+	}
+	for name, project := range layer.Projects {
+		if c.Projects == nil {
+			c.Projects = map[string]*Configuration{}
+		}
+		c.Projects[name] = project
+	}
+	for name, profile := range layer.Profile {
+		if c.Profile == nil {
+			c.Profile = map[string]*Profile{}
+		}
+		c.Profile[name] = profile
+	}
+	if layer.Definitions != nil {
+		if c.Definitions == nil {
+			c.Definitions = &Definitions{}
+		}
+		c.Definitions.Task = mergeTasks(c.Definitions.Task, layer.Definitions.Task)
+		c.Definitions.File = mergeFiles(c.Definitions.File, layer.Definitions.File)
+	}
+	if layer.Requires != nil {
+		if c.Requires == nil {
+			c.Requires = &Requires{}
+		}
+		c.Requires.Env = mergeStrings(c.Requires.Env, layer.Requires.Env)
+		c.Requires.Commands = mergeStrings(c.Requires.Commands, layer.Requires.Commands)
+		c.Requires.Files = mergeStrings(c.Requires.Files, layer.Requires.Files)
+	}
+}
+
+// mergeStrings returns the values with every layer value not already present appended
+func mergeStrings(values []string, layer []string) []string {
+	for _, value := range layer {
+		if !contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// mergeString replaces the value with layer when layer is not empty
+func mergeString(value *string, layer string) {
+	if len(layer) > 0 {
+		*value = layer
+	}
+}
+
+// mergeTasks returns the tasks with every layer Task replacing the Task of the same name, or appended
+func mergeTasks(tasks []*Task, layer []*Task) []*Task {
+	for _, task := range layer {
+		if task == nil {
+			continue
+		}
+		replaced := false
+		for i, t := range tasks {
+			if t != nil && t.Name == task.Name {
+				tasks[i] = task
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// mergeFiles returns the files with every layer File replacing the File of the same name, or of the same types when unnamed, or appended
+func mergeFiles(files []*File, layer []*File) []*File {
+	for _, file := range layer {
+		if file == nil {
+			continue
+		}
+		replaced := false
+		for i, f := range files {
+			if f != nil && fileKey(f) == fileKey(file) {
+				files[i] = file
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// fileKey returns the key files are merged by: the name, or the types of a File without a name
+func fileKey(f *File) string {
+	if len(f.Name) > 0 {
+		return "name:" + f.Name
+	}
+	return "type:" + strings.Join(f.Type, ",")
+}
+
+// indexScript returns the index of the named Script, or -1 if not found
+func indexScript(scripts []*Script, name string) int {
+	for i, s := range scripts {
+		if s != nil && s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
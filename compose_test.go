@@ -0,0 +1,66 @@
+package configuration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestCompose(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base.json")
+	os.WriteFile(base, []byte(`{"name":"base","task":[{"name":"lorem","path":{"include":["*"]}}],"file":[{"type":["go"],"parse":{"comment":{"line":"//"}}}]}`), 0644)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"script":[{"name":"build","task":["lorem"]}]}`))
+	}))
+	defer server.Close()
+	os.Setenv("EMITS_TEST_COMPOSE", `{"name":"env","task":[{"name":"lorem","path":{"include":["*.go"]}}]}`)
+	defer os.Unsetenv("EMITS_TEST_COMPOSE")
+	stdin := strings.NewReader(`{"description":"stdin","file":[{"type":["go"],"parse":{"comment":{"line":"#"}}}]}`)
+	c, report, err := configuration.Compose(
+		configuration.FileSource(base),
+		configuration.URLSource(server.URL+"/remote.json"),
+		configuration.EnvSource("EMITS_TEST_COMPOSE", configuration.JSON),
+		configuration.ReaderSource("stdin", stdin, configuration.JSON),
+	)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !report.Valid() {
+		t.Errorf("Expecting valid, got %v", report.Errors)
+	}
+	if c.Name != "env" || c.Description != "stdin" {
+		t.Errorf("Expecting env and stdin, got %v %v", c.Name, c.Description)
+	}
+	if len(c.Task) != 1 || c.Task[0].Path.Include[0] != "*.go" {
+		t.Errorf("Expecting replaced lorem task, got %v", c.Task)
+	}
+	if len(c.Script) != 1 || len(c.File) != 1 || c.File[0].Parse.Comment.Line != "#" {
+		t.Errorf("Expecting merged script and file, got %v %v", c.Script, c.File)
+	}
+	_, _, err = configuration.Compose(configuration.FileSource(filepath.Join(t.TempDir(), "missing.json")))
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestCompose_Prepare(t *testing.T) {
+	t.Setenv("EMITS_TEST_COMPOSE_NAME", "lorem")
+	path := filepath.Join(t.TempDir(), "emits.json")
+	os.WriteFile(path, []byte(`{"name":"{{env.EMITS_TEST_COMPOSE_NAME}}","var":{"src":"src"},"task":[{"name":"lorem","path":{"include":["{{var.src}}/**"]}}]}`), 0644)
+	c, _, err := configuration.Compose(configuration.FileSource(path))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.LoadFrom(path); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem" || c.Task[0].Path.Include[0] != "src/**" || c.Task[0].Path.Include[0] != loaded.Task[0].Path.Include[0] {
+		t.Errorf("Expecting lorem and src/** like LoadFrom, got %v %v", c.Name, c.Task[0].Path.Include)
+	}
+}
@@ -1,10 +1,14 @@
 package configuration
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/emits-io/core"
@@ -17,40 +21,73 @@ const (
 
 // Configuration contains all options used to establish processing of ConfigFile
 type Configuration struct {
-	Name        string    `json:"name,omitempty"`
-	Description string    `json:"description,omitempty"`
-	Author      string    `json:"author,omitempty"`
-	License     string    `json:"license,omitempty"`
-	Version     string    `json:"version,omitempty"`
-	Task        []*Task   `json:"task,omitempty"`
-	Script      []*Script `json:"script,omitempty"`
-	File        []*File   `json:"file,omitempty"`
+	Schema        string                    `json:"$schema,omitempty"`
+	Extends       string                    `json:"extends,omitempty"`
+	Include       []string                  `json:"include,omitempty"`
+	SchemaVersion int                       `json:"schemaVersion,omitempty"`
+	Name          string                    `json:"name,omitempty"`
+	Description   string                    `json:"description,omitempty"`
+	Author        string                    `json:"author,omitempty"`
+	License       string                    `json:"license,omitempty"`
+	Version       string                    `json:"version,omitempty"`
+	Task          []*Task                   `json:"task,omitempty"`
+	Script        []*Script                 `json:"script,omitempty"`
+	File          []*File                   `json:"file,omitempty"`
+	Definitions   *Definitions              `json:"definitions,omitempty"`
+	Requires      *Requires                 `json:"requires,omitempty"`
+	Var           map[string]string         `json:"var,omitempty"`
+	Profile       map[string]*Profile       `json:"profile,omitempty"`
+	Projects      map[string]*Configuration `json:"projects,omitempty"`
+	changelog     changelog
+	workspace     *Configuration
 }
 
 // Script contains all the options used to establish a script on Configuration
 type Script struct {
-	Name string   `json:"name,omitempty"`
-	Task []string `json:"task,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Task       []string `json:"task,omitempty"`
+	Param      []*Param `json:"param,omitempty"`
+	Disjoint   bool     `json:"disjoint,omitempty"`
+	Dedupe     bool     `json:"dedupe,omitempty"`
+	Notes      string   `json:"notes,omitempty"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	Disabled   bool     `json:"disabled,omitempty"`
 }
 
 // Task contains all the options used to establish a task on Configuration
 type Task struct {
-	Name string `json:"name,omitempty"`
-	Path *Path  `json:"path,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Extends    string   `json:"extends,omitempty"`
+	Path       *Path    `json:"path,omitempty"`
+	Param      []*Param `json:"param,omitempty"`
+	Inputs     []string `json:"inputs,omitempty"`
+	Outputs    []string `json:"outputs,omitempty"`
+	Notes      string   `json:"notes,omitempty"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	Disabled   bool     `json:"disabled,omitempty"`
 }
 
-// Path contains all the options used to establish a path on Task
+// Path contains all the options used to establish a path on Task; MaxDepth limits the directory levels walked, 0 for no limit, and Hidden set to false skips dotfiles and dot directories, which are otherwise left to the patterns
 type Path struct {
-	Include []string `json:"include,omitempty"`
-	Exclude []string `json:"exclude,omitempty"`
+	Include   []string `json:"include,omitempty"`
+	Exclude   []string `json:"exclude,omitempty"`
+	Gitignore bool     `json:"gitignore,omitempty"`
+	Symlinks  Symlinks `json:"symlinks,omitempty"`
+	MaxDepth  int      `json:"maxDepth,omitempty"`
+	Hidden    *bool    `json:"hidden,omitempty"`
 }
 
 // File contains all the options used to establish a file on Configuration
 type File struct {
-	Type   []string `json:"type,omitempty"`
-	Parse  *Parse   `json:"parse,omitempty"`
-	Modify *Modify  `json:"modify,omitempty"`
-	Audit  []*Audit `json:"audit,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Extends  string   `json:"extends,omitempty"`
+	Type     []string `json:"type,omitempty"`
+	Parse    *Parse   `json:"parse,omitempty"`
+	Modify   *Modify  `json:"modify,omitempty"`
+	Audit    []*Audit `json:"audit,omitempty"`
+	Output   string   `json:"output,omitempty"`
+	Notes    string   `json:"notes,omitempty"`
+	Disabled bool     `json:"disabled,omitempty"`
 }
 
 // Audit contains all the options used to establish an audit on File
@@ -60,121 +97,454 @@ type Audit struct {
 	Exclude []string `json:"exclude,omitempty"`
 }
 
-// Modify contains all the options used to establish a modify on File
+// Modify contains all the options used to establish a modify on File; Disabled skips every plugin and regex step
 type Modify struct {
-	Plugin []*Plugin                 `json:"plugin,omitempty"`
-	Regex  []*core.RegularExpression `json:"regex,omitempty"`
+	Plugin   []*Plugin                 `json:"plugin,omitempty"`
+	Regex    []*core.RegularExpression `json:"regex,omitempty"`
+	Disabled bool                      `json:"disabled,omitempty"`
 }
 
 // Parse contains all the options used to establish a parse on File
 type Parse struct {
 	Comment *core.Comment `json:"comment,omitempty"`
 	Source  bool          `json:"source,omitempty"`
+	Exclude []string      `json:"exclude,omitempty"`
 }
 
 // Plugin contains all the options used to establish a plugin on File
 type Plugin struct {
-	Path string `json:"path,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
 }
 
-func (c *Configuration) Write() error {
-	data, err := json.MarshalIndent(c, "", "\t")
+// Write encodes Configuration to ConfigFile, or to the file of the WithFormat Codec; WithVerify re-reads the file afterwards
+func (c *Configuration) Write(options ...Option) error {
+	return c.WriteToPath(".", options...)
+}
+
+// WriteToPath encodes Configuration to the file at path with the WithFormat Codec, or the Codec of its extension, holding its exclusive lock while writing; a directory path receives the file Write would create
+func (c *Configuration) WriteToPath(path string, options ...Option) error {
+	o := newOptions(options)
+	path, codec, err := writeTarget(path, o)
+	if err != nil {
+		return err
+	}
+	unlock, err := acquireLock(o.cacheDir(), path)
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(ConfigFile, data, 0644)
+	defer unlock()
+	return c.writeFile(path, codec, o)
+}
+
+// writeTarget returns the file WriteToPath writes for path, within path when it is a directory, and the WithFormat Codec or the Codec of its extension; within a directory the file Load selects is written unless WithFormat names another format
+func writeTarget(path string, o *options) (string, Codec, error) {
+	codec := FindCodec(o.format)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if len(configFiles(os.DirFS(path))) > 0 {
+			selected, err := configFileIn(path)
+			if err != nil {
+				return "", nil, err
+			}
+			if len(o.format) == 0 || codec != nil && codecFor(selected).Name() == codec.Name() {
+				return selected, codecFor(selected), nil
+			}
+		}
+		if codec == nil && len(o.format) == 0 {
+			codec = FindCodec(JSON)
+		}
+		if codec == nil {
+			return "", nil, fmt.Errorf("unsupported format `%s`", o.format)
+		}
+		return filepath.Join(path, formatFile(codec)), codec, nil
+	}
+	if len(o.format) == 0 {
+		codec = codecFor(path)
+	}
+	if codec == nil {
+		return "", nil, fmt.Errorf("unsupported format `%s`", o.format)
+	}
+	return path, codec, nil
+}
+
+// writeFile encodes Configuration to the file at path with the Codec, replacing it atomically with its current permissions; the caller holds the lock of path
+func (c *Configuration) writeFile(path string, codec Codec, o *options) error {
+	data, written, err := c.encode(codec, o)
 	if err != nil {
 		return err
 	}
+	if err := rotateBackups(path, data, o.backups); err != nil {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeAtomic(path, data, perm); err != nil {
+		return err
+	}
+	if o.verify {
+		return written.verify(path, codec, data)
+	}
 	return nil
 }
 
-// Load attempts to open ConfigFile and returns any errors from Validate()
-func (c *Configuration) Load() error {
-	jsonFile, err := os.Open(ConfigFile)
+// WriteTo writes Configuration to the writer as ConfigFile would hold it, implementing io.WriterTo
+func (c *Configuration) WriteTo(w io.Writer) (int64, error) {
+	if c == nil {
+		return 0, nullConfiguration()
+	}
+	data, _, err := c.encode(FindCodec(JSON), newOptions(nil))
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Encode writes Configuration to the writer with the WithFormat Codec, or JSON
+func (c *Configuration) Encode(w io.Writer, options ...Option) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	o := newOptions(options)
+	codec := FindCodec(o.format)
+	if len(o.format) == 0 {
+		codec = FindCodec(JSON)
+	}
+	if codec == nil {
+		return fmt.Errorf("unsupported format `%s`", o.format)
+	}
+	data, _, err := c.encode(codec, o)
 	if err != nil {
 		return err
 	}
-	defer jsonFile.Close()
-	byteValue, err := ioutil.ReadAll(jsonFile)
+	_, err = w.Write(data)
+	return err
+}
+
+// encode returns Configuration encoded with the Codec and the Configuration actually encoded, which differs when WithProjectRoot rewrites paths or WithSchema sets `$schema`
+func (c *Configuration) encode(codec Codec, o *options) ([]byte, *Configuration, error) {
+	written := c
+	if len(o.root) > 0 || len(o.schema) > 0 && o.schema != c.Schema {
+		written = c.Clone()
+	}
+	if len(o.root) > 0 {
+		if err := written.relativize(o.root); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(o.schema) > 0 {
+		written.Schema = o.schema
+	}
+	data, err := codec.Marshal(written)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, written, nil
+}
+
+// Load attempts to open the file ConfigFileFS selects in the current directory, and interpolates environment variable references; returns an error wrapping ErrConflictingFiles when several configuration files of different content are present
+func (c *Configuration) Load(options ...Option) error {
+	return c.LoadFrom(".", options...)
+}
+
+// LoadFrom attempts to open the file at path, the file Load would select within a directory path, the document at an http or https url, or the file at a `git+<repository>#<ref>/<path>` location, and interpolates environment variable references
+func (c *Configuration) LoadFrom(path string, options ...Option) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if path, err = configFileIn(path); err != nil {
+			return err
+		}
+	}
+	err := c.load(path, options...)
 	if err != nil {
 		return err
 	}
-	if json.Unmarshal(byteValue, &c) != nil {
+	return c.prepare(options...)
+}
+
+// LoadContext is Load bounded by ctx: remote files are fetched with ctx and loading stops with its error once ctx is done
+func (c *Configuration) LoadContext(ctx context.Context, options ...Option) error {
+	return c.LoadFromContext(ctx, ".", options...)
+}
+
+// LoadFromContext is LoadFrom bounded by ctx: remote files are fetched with ctx and loading stops with its error once ctx is done
+func (c *Configuration) LoadFromContext(ctx context.Context, path string, options ...Option) error {
+	return c.LoadFrom(path, append(options[:len(options):len(options)], withContext(ctx))...)
+}
+
+// LoadReader decodes Configuration from the reader with the WithFormat Codec, or JSON, and interpolates environment variable references
+func (c *Configuration) LoadReader(r io.Reader, options ...Option) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	o := newOptions(options)
+	codec := FindCodec(o.format)
+	if len(o.format) == 0 {
+		codec = FindCodec(JSON)
+	}
+	if codec == nil {
+		return fmt.Errorf("unsupported format `%s`", o.format)
+	}
+	err := c.read(r, codec, "", o)
+	if err != nil {
 		return err
 	}
-	jsonFile.Close()
-	return nil
+	if err := c.assemble(".", nil, options...); err != nil {
+		return err
+	}
+	return c.prepare(options...)
 }
 
-// Validate returns all known validation errors at once, rather than one at a time
-func (c *Configuration) Validate() []error {
-	var errors []error
-	err := c.ValidateTaskDefinitionExists()
+// prepare resolves var references, then applies WithProjectRoot and environment variable interpolation to a freshly decoded Configuration
+func (c *Configuration) prepare(options ...Option) error {
+	if err := c.ResolveVars(); err != nil {
+		return err
+	}
+	if o := newOptions(options); len(o.root) > 0 {
+		if err := c.absolutize(o.root); err != nil {
+			return err
+		}
+	}
+	return c.Interpolate(options...)
+}
+
+// load attempts to open the provided path and decode it into Configuration, with the fragments it includes merged in, merged over the base configuration file it extends and with the ConfigDir fragments next to it merged last; a url is fetched like a remote extends, without ConfigDir fragments
+func (c *Configuration) load(path string, options ...Option) error {
+	if remote(path) || isGit(path) {
+		location, sum := splitPin(path)
+		options = append(options[:len(options):len(options)], withAuthLocation(location))
+		if err := c.decodeLinked(location, sum, options...); err != nil {
+			return err
+		}
+		return c.assemble(linkedDir(location), []string{location}, options...)
+	}
+	err := c.decodeFile(path, options...)
 	if err != nil {
-		errors = append(errors, err)
+		return err
 	}
-	err = c.ValidateFileDefinitionExists()
+	absolute, err := filepath.Abs(path)
 	if err != nil {
-		errors = append(errors, err)
+		return err
 	}
-	for _, task := range c.Task {
-		errTaskDefinition := task.Validate()
-		if errTaskDefinition != nil {
-			errors = append(errors, errTaskDefinition...)
+	if err := c.assemble(filepath.Dir(path), []string{absolute}, options...); err != nil {
+		return err
+	}
+	return c.mergeDropIns(filepath.Dir(path), options...)
+}
+
+// decodeFile attempts to open the provided path and decode it into Configuration with the Codec of its extension; writers replace files atomically, so no lock is taken
+func (c *Configuration) decodeFile(path string, options ...Option) error {
+	return c.decodePinned(path, "", options...)
+}
+
+// decodePinned reads the file at path once, verifies the bytes read against the pinned sha256 sum, if any, and decodes those same bytes as decodeFile does
+func (c *Configuration) decodePinned(path string, sum string, options ...Option) error {
+	o := newOptions(options)
+	if err := o.context().Err(); err != nil {
+		return err
+	}
+	if o.sources != nil {
+		*o.sources = append(*o.sources, path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := verifyPin(path, data, sum); err != nil {
+		return err
+	}
+	err = c.read(bytes.NewReader(data), codecFor(path), filepath.Dir(path), o)
+	if e, ok := err.(*DecodeError); ok {
+		e.Path = path
+	}
+	return err
+}
+
+// read decodes the reader into Configuration with the Codec; WithTolerant decodes JSON as JSONC and WithStrict rejects unknown fields; JSON decoder errors are returned as DecodeError
+func (c *Configuration) read(r io.Reader, codec Codec, dir string, o *options) error {
+	byteValue, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	evaluator, evaluated := codec.(Evaluator)
+	if evaluated {
+		if byteValue, err = evaluator.Evaluate(byteValue, dir); err != nil {
+			return err
 		}
+		codec = FindCodec(JSON)
 	}
-	for _, file := range c.File {
-		errFileDefinition := file.Validate()
-		if errFileDefinition != nil {
-			errors = append(errors, errFileDefinition...)
+	if o.tolerant && codec.Name() == string(JSON) {
+		codec = FindCodec(JSONC)
+	}
+	if o.strict {
+		unknown, err := UnknownFields(byteValue, Format(codec.Name()))
+		if err != nil {
+			return err
+		}
+		if unknown != nil {
+			return Errors(unknown)
 		}
 	}
-	for _, script := range c.Script {
-		errScriptDefinition := script.Validate(c)
-		if errScriptDefinition != nil {
-			errors = append(errors, errScriptDefinition...)
+	if o.validate {
+		mismatched, err := ValidateDocument(byteValue, Format(codec.Name()))
+		if err != nil {
+			return err
+		}
+		if mismatched != nil {
+			return Errors(mismatched)
+		}
+	}
+	err = codec.Unmarshal(byteValue, c)
+	if err != nil && !evaluated && (codec.Name() == string(JSON) || codec.Name() == string(JSONC)) {
+		return decodeError(byteValue, err)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate returns all known validation errors at once, rather than one at a time; options may bound the number of errors returned
+func (c *Configuration) Validate(options ...Option) []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	o := newOptions(options)
+	checks := []func() []error{
+		func() []error {
+			return single(c.ValidateSchemaVersion())
+		},
+		c.ValidateVars,
+		func() []error {
+			return single(c.ValidateTaskDefinitionExists())
+		},
+		func() []error {
+			return single(c.ValidateFileDefinitionExists())
+		},
+	}
+	for i, task := range c.Task {
+		i, task := i, task
+		checks = append(checks, func() []error {
+			if task == nil {
+				return []error{newError(CodeMissing, pointer("task", i), "task definition at index `%v` is null", i)}
+			}
+			return prefix(pointer("task", i), c.resolve(task).Validate())
+		})
+	}
+	checks = append(checks, c.ValidateTaskExtends, c.ValidateFileExtends, c.ValidateTaskDependencies, c.ValidateProjects, func() []error {
+		return c.Definitions.Validate(c)
+	}, func() []error {
+		return prefix(pointer("requires"), c.Requires.Validate())
+	})
+	for i, file := range c.File {
+		i, file := i, file
+		checks = append(checks, func() []error {
+			if file == nil {
+				return []error{newError(CodeMissing, pointer("file", i), "file definition at index `%v` is null", i)}
+			}
+			return prefix(pointer("file", i), c.resolveFile(file).Validate())
+		})
+	}
+	for i, script := range c.Script {
+		i, script := i, script
+		checks = append(checks, func() []error {
+			if script == nil {
+				return []error{newError(CodeMissing, pointer("script", i), "script definition at index `%v` is null", i)}
+			}
+			return prefix(pointer("script", i), script.Validate(c))
+		})
+	}
+	var errors []error
+	for i, check := range checks {
+		if err := o.context().Err(); err != nil {
+			errors = append(errors, newError(CodeIncomplete, "", "validation stopped after %d of %d checks: %v; remaining checks skipped", i, len(checks), err))
+			break
+		}
+		errCheck := check()
+		if errCheck != nil {
+			errors = append(errors, errCheck...)
+		}
+		if o.limit() > 0 && len(errors) >= o.limit() {
+			return errors[:o.limit()]
 		}
 	}
 	return errors
 }
 
+// ValidateContext is Validate bounded by ctx: once ctx is done the remaining checks are skipped and reported as an error of CodeIncomplete
+func (c *Configuration) ValidateContext(ctx context.Context, options ...Option) []error {
+	return c.Validate(append(options[:len(options):len(options)], withContext(ctx))...)
+}
+
+// single returns the error as a slice, or nil if the error is nil
+func single(err error) []error {
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
+
 func (c *Configuration) ValidateTaskDefinitionExists() error {
-	if len(c.Task) == 0 {
-		return fmt.Errorf("`%s` must contain at least one task definition", ConfigFile)
+	if c == nil {
+		return nullConfiguration()
+	}
+	if len(c.Task) == 0 && len(c.ProjectNames()) == 0 {
+		return newError(CodeMissing, pointer("task"), "`%s` must contain at least one task definition", ConfigFile)
 	}
 	return nil
 }
 
 func (c *Configuration) ValidateFileDefinitionExists() error {
-	if len(c.File) == 0 {
-		return fmt.Errorf("`%s` must contain at least one file definition", ConfigFile)
+	if c == nil {
+		return nullConfiguration()
+	}
+	if len(c.File) == 0 && len(c.ProjectNames()) == 0 {
+		return newError(CodeMissing, pointer("file"), "`%s` must contain at least one file definition", ConfigFile)
 	}
 	return nil
 }
 
 func (f *File) Validate() []error {
 	var errors []error
+	if f == nil {
+		return append(errors, newError(CodeMissing, "", "file definition is null"))
+	}
 	if len(f.Type) == 0 {
 		f.Type = []string{fmt.Sprintf("%v", &f)}
-		errors = append(errors, fmt.Errorf("`%s` file missing type definition", strings.Join(f.Type, ",")))
+		errors = append(errors, newError(CodeMissing, pointer("type"), "`%s` file missing type definition", strings.Join(f.Type, ",")))
+	}
+	errOutputDefinition := f.ValidateOutput()
+	if errOutputDefinition != nil {
+		errors = append(errors, errOutputDefinition...)
 	}
 	errParseDefinition := f.Parse.Validate(f)
 	if errParseDefinition != nil {
-		errors = append(errors, errParseDefinition...)
+		errors = append(errors, prefix(pointer("parse"), errParseDefinition)...)
 	}
 	if f.Modify != nil {
 		if f.Modify.Plugin != nil {
 			for i, plugin := range f.Modify.Plugin {
+				if plugin == nil {
+					continue
+				}
 				if len(plugin.Path) == 0 {
-					errors = append(errors, fmt.Errorf("`%s` file modify plugin path definition at index `%v` is empty", strings.Join(f.Type, ","), i))
+					errors = append(errors, newError(CodeEmpty, pointer("modify", "plugin", i, "path"), "`%s` file modify plugin path definition at index `%v` is empty", strings.Join(f.Type, ","), i))
 				}
 			}
 		}
 		if f.Modify.Regex != nil {
 			for i, regex := range f.Modify.Regex {
-				if len(regex.Find) == 0 {
-					errors = append(errors, fmt.Errorf("`%s` file modify find definition at index `%v` is empty", strings.Join(f.Type, ","), i))
+				if regex == nil {
+					continue
+				}
+				errRegexDefinition := validateRegex(f, i, regex)
+				if errRegexDefinition != nil {
+					errors = append(errors, errRegexDefinition...)
 				}
 			}
 		}
@@ -184,60 +554,116 @@ func (f *File) Validate() []error {
 
 func (p *Parse) Validate(f *File) []error {
 	var errors []error
+	if f == nil {
+		f = &File{}
+	}
 	if p == nil {
-		errors = append(errors, fmt.Errorf("file `%s` type missing parse definition", strings.Join(f.Type, ",")))
+		errors = append(errors, newError(CodeMissing, "", "file `%s` type missing parse definition", strings.Join(f.Type, ",")))
 	} else {
 		if p.Comment == nil || p.Comment != nil && len(p.Comment.Line) == 0 && p.Comment.Block == nil {
-			errors = append(errors, fmt.Errorf("file `%s` type missing parse comment definition", strings.Join(f.Type, ",")))
+			errors = append(errors, newError(CodeMissing, pointer("comment"), "file `%s` type missing parse comment definition", strings.Join(f.Type, ",")))
 		} else if p.Comment.Block != nil {
 			if len(p.Comment.Block.Start) == 0 {
-				errors = append(errors, fmt.Errorf("file `%s` type missing parse block comment start definition", strings.Join(f.Type, ",")))
+				errors = append(errors, newError(CodeMissing, pointer("comment", "block", "start"), "file `%s` type missing parse block comment start definition", strings.Join(f.Type, ",")))
 			}
 			if len(p.Comment.Block.End) == 0 {
-				errors = append(errors, fmt.Errorf("file `%s` type missing parse block comment end definition", strings.Join(f.Type, ",")))
+				errors = append(errors, newError(CodeMissing, pointer("comment", "block", "end"), "file `%s` type missing parse block comment end definition", strings.Join(f.Type, ",")))
+			}
+		}
+		for i, exclude := range p.Exclude {
+			if len(strings.TrimSpace(exclude)) == 0 {
+				errors = append(errors, newError(CodeEmpty, pointer("exclude", i), "file `%s` type parse exclude definition at index `%v` is empty", strings.Join(f.Type, ","), i))
+			} else if _, err := path.Match(exclude, ""); err != nil {
+				errors = append(errors, newError(CodeInvalid, pointer("exclude", i), "file `%s` type parse exclude definition at index `%v` is invalid: %v", strings.Join(f.Type, ","), i, err))
 			}
 		}
 	}
 	return errors
 }
 
+// Excludes returns true if the slash separated file path, or any of its parent directories, matches a Parse Exclude pattern; patterns without a `/` match any single path segment
+func (p *Parse) Excludes(file string) bool {
+	if p == nil {
+		return false
+	}
+	file = path.Clean(strings.TrimPrefix(file, "./"))
+	for _, exclude := range p.Exclude {
+		exclude = strings.TrimSuffix(strings.TrimPrefix(exclude, "./"), "/")
+		for dir := file; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			name := dir
+			if !strings.Contains(exclude, "/") {
+				name = path.Base(dir)
+			}
+			if matched, _ := path.Match(exclude, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (t *Task) Validate() []error {
 	var errors []error
+	if t == nil {
+		return append(errors, newError(CodeMissing, "", "task definition is null"))
+	}
 	if len(t.Name) == 0 {
 		t.Name = fmt.Sprintf("%v", &t)
-		errors = append(errors, fmt.Errorf("`%s` task missing name definition", t.Name))
+		errors = append(errors, newError(CodeMissing, pointer("name"), "`%s` task missing name definition", t.Name))
 	}
 	if t.Path != nil {
 		if t.Path.Include == nil {
-			errors = append(errors, fmt.Errorf("`%s` task missing path include definition", t.Name))
+			errors = append(errors, newError(CodeMissing, pointer("path", "include"), "`%s` task missing path include definition", t.Name))
 		}
 		for i, include := range t.Path.Include {
 			if len(strings.TrimSpace(include)) == 0 {
-				errors = append(errors, fmt.Errorf("`%s` task path include definition at index `%v` is empty", t.Name, i))
+				errors = append(errors, newError(CodeEmpty, pointer("path", "include", i), "`%s` task path include definition at index `%v` is empty", t.Name, i))
+			} else if len(strings.TrimSpace(strings.TrimPrefix(include, "!"))) == 0 {
+				errors = append(errors, newError(CodeEmpty, pointer("path", "include", i), "`%s` task path include negation at index `%v` is empty", t.Name, i))
+			} else if err := validPattern(strings.TrimPrefix(include, "!")); err != nil {
+				errors = append(errors, newError(CodeInvalid, pointer("path", "include", i), "`%s` task path include definition `%s` at index `%v` is invalid: %v", t.Name, include, i, err))
 			}
 		}
 		for i, exclude := range t.Path.Exclude {
 			if len(strings.TrimSpace(exclude)) == 0 {
-				errors = append(errors, fmt.Errorf("`%s` task path exclude definition at index `%v` is empty", t.Name, i))
+				errors = append(errors, newError(CodeEmpty, pointer("path", "exclude", i), "`%s` task path exclude definition at index `%v` is empty", t.Name, i))
+			} else if err := validPattern(exclude); err != nil {
+				errors = append(errors, newError(CodeInvalid, pointer("path", "exclude", i), "`%s` task path exclude definition `%s` at index `%v` is invalid: %v", t.Name, exclude, i, err))
 			}
 		}
+		if t.Path.MaxDepth < 0 {
+			errors = append(errors, newError(CodeInvalid, pointer("path", "maxDepth"), "`%s` task path maxDepth definition `%v` is invalid, expecting 0 for no limit or more", t.Name, t.Path.MaxDepth))
+		}
+		if !t.Path.Symlinks.valid() {
+			errors = append(errors, newError(CodeInvalid, pointer("path", "symlinks"), "`%s` task path symlinks definition `%s` is invalid, expecting `%s`, `%s` or `%s`", t.Name, t.Path.Symlinks, SymlinkFollow, SymlinkSkip, SymlinkError))
+		}
 	} else {
-		errors = append(errors, fmt.Errorf("`%s` task missing path definition", t.Name))
+		errors = append(errors, newError(CodeMissing, pointer("path"), "`%s` task missing path definition", t.Name))
+	}
+	errParamDefinition := validateParams("task", t.Name, t.Param)
+	if errParamDefinition != nil {
+		errors = append(errors, errParamDefinition...)
 	}
 	return errors
 }
 
 func (s *Script) Validate(c *Configuration) []error {
 	var errors []error
+	if s == nil {
+		return append(errors, newError(CodeMissing, "", "script definition is null"))
+	}
+	if c == nil {
+		c = &Configuration{}
+	}
 	if len(s.Name) == 0 {
 		s.Name = fmt.Sprintf("%v", &s)
-		errors = append(errors, fmt.Errorf("`%s` script missing name definition", s.Name))
+		errors = append(errors, newError(CodeMissing, pointer("name"), "`%s` script missing name definition", s.Name))
 	}
 	if len(s.Task) == 0 {
-		errors = append(errors, fmt.Errorf("`%s` script must contain at least one task definition", s.Name))
+		errors = append(errors, newError(CodeMissing, pointer("task"), "`%s` script must contain at least one task definition", s.Name))
 	} else {
 		var seenTask []string
-		for _, task := range s.Task {
+		for i, task := range s.Task {
 			taskSeen := false
 			for _, seen := range seenTask {
 				if seen == task {
@@ -246,31 +672,66 @@ func (s *Script) Validate(c *Configuration) []error {
 				}
 			}
 			if taskSeen {
-				errors = append(errors, fmt.Errorf("`%s` script referencing duplicate `%s` task definition", s.Name, task))
+				errors = append(errors, newError(CodeDuplicate, pointer("task", i), "`%s` script referencing duplicate `%s` task definition", s.Name, task))
 			} else {
 				seenTask = append(seenTask, task)
 			}
 			if c.FindTask(task) == nil {
-				errors = append(errors, fmt.Errorf("`%s` script referencing unknown `%s` task definition", s.Name, task))
+				errors = append(errors, newError(CodeUnknown, pointer("task", i), "`%s` script referencing unknown `%s` task definition", s.Name, task).suggest(task, c.taskNames()))
 			}
 		}
 	}
+	errParamDefinition := s.ValidateParams(c)
+	if errParamDefinition != nil {
+		errors = append(errors, errParamDefinition...)
+	}
+	errDisjointDefinition := s.ValidateDisjoint(c)
+	if errDisjointDefinition != nil {
+		errors = append(errors, errDisjointDefinition...)
+	}
 	return errors
 }
 
-// FindTask returns the Task if found or nil if not found; used to validate Script Task references
+// FindTask returns the Task if found, or the Task of another project referenced as `project:task`, or nil if not found; used to validate Script Task references
 func (c *Configuration) FindTask(name string) *Task {
+	if c == nil {
+		return nil
+	}
 	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
 		if t.Name == name {
 			return t
 		}
 	}
-	return nil
+	return c.projectTask(name)
+}
+
+// taskNames returns the name of every Task on Configuration
+func (c *Configuration) taskNames() []string {
+	var names []string
+	if c == nil {
+		return names
+	}
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		names = append(names, t.Name)
+	}
+	return names
 }
 
 // FindScript returns the Script if found or nil if not found; used to validate Script references
 func (c *Configuration) FindScript(name string) *Script {
+	if c == nil {
+		return nil
+	}
 	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
 		if s.Name == name {
 			return s
 		}
@@ -1,9 +1,14 @@
 package configuration_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
 	"github.com/emits-io/configuration"
 	"github.com/emits-io/core"
-	"testing"
 )
 
 func TestConfiguration_Write(t *testing.T) {
@@ -49,10 +54,10 @@ func TestConfiguration_Write(t *testing.T) {
 				Modify: &configuration.Modify{
 					Plugin: []*configuration.Plugin{
 						{
-							"./foo.js",
+							Path: "./foo.js",
 						},
 						{
-							"./bar.js",
+							Path: "./bar.js",
 						},
 					},
 					Regex: []*core.RegularExpression{
@@ -245,6 +250,21 @@ func TestTask_Validate(t *testing.T) {
 	if err == nil {
 		t.Errorf("Expecting error, got nil")
 	}
+	task.Path = &configuration.Path{
+		Include: []string{"src/**/*.{go,mod}", "[a-", "!src/gen/**", "!"},
+		Exclude: []string{"src/{a,b"},
+	}
+	err = task.Validate()
+	if len(err) != 3 || configuration.ErrorCode(err[0]) != configuration.CodeInvalid || configuration.Pointer(err[0]) != "/path/include/1" || configuration.Pointer(err[1]) != "/path/include/3" || configuration.Pointer(err[2]) != "/path/exclude/0" {
+		t.Errorf("Expecting invalid include, empty negation and invalid exclude, got %v", err)
+	}
+	task.Path = &configuration.Path{
+		Include: []string{strings.Repeat("{a,b}", 22), strings.Repeat("{a,b}", 10)},
+	}
+	err = task.Validate()
+	if len(err) != 1 || configuration.Pointer(err[0]) != "/path/include/0" {
+		t.Errorf("Expecting too many alternatives, got %v", err)
+	}
 }
 
 func TestScript_Validate(t *testing.T) {
@@ -302,3 +322,96 @@ func TestConfiguration_FindScript(t *testing.T) {
 		t.Errorf("Expecting nil, got script %v", script)
 	}
 }
+
+func TestParse_Excludes(t *testing.T) {
+	f := &configuration.File{
+		Type: []string{"go"},
+	}
+	p := &configuration.Parse{
+		Comment: &core.Comment{
+			Line: "//",
+		},
+		Exclude: []string{"vendor/", "*.pb.go", "internal/gen/*"},
+	}
+	err := p.Validate(f)
+	if err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+	if !p.Excludes("vendor/github.com/emits-io/core/core.go") {
+		t.Errorf("Expecting true, got false")
+	}
+	if !p.Excludes("./api.pb.go") {
+		t.Errorf("Expecting true, got false")
+	}
+	if !p.Excludes("internal/gen/model.go") {
+		t.Errorf("Expecting true, got false")
+	}
+	if p.Excludes("internal/model.go") {
+		t.Errorf("Expecting false, got true")
+	}
+	p.Exclude = []string{"", "[vendor"}
+	err = p.Validate(f)
+	if len(err) != 2 {
+		t.Errorf("Expecting 2 errors, got %v", err)
+	}
+}
+
+func TestConfiguration_LoadFrom(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "packages", "web")
+	os.MkdirAll(dir, 0755)
+	c := &configuration.Configuration{
+		Name: "web",
+	}
+	if err := c.WriteToPath(dir); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, configuration.ConfigFile)); err != nil {
+		t.Errorf("Expecting %v, got %v", configuration.ConfigFile, err)
+	}
+	fixture := filepath.Join(dir, "fixture.yaml")
+	if err := c.WriteToPath(fixture); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.LoadFrom(dir); err != nil || loaded.Name != "web" {
+		t.Errorf("Expecting web, got %v %v", err, loaded.Name)
+	}
+	loaded = &configuration.Configuration{}
+	if err := loaded.LoadFrom(fixture); err != nil || loaded.Name != "web" {
+		t.Errorf("Expecting web, got %v %v", err, loaded.Name)
+	}
+	if err := loaded.LoadFrom(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestConfiguration_LoadReader(t *testing.T) {
+	c := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{
+				Name: "ipsum",
+			},
+		},
+	}
+	var buffer bytes.Buffer
+	n, err := c.WriteTo(&buffer)
+	if err != nil || n != int64(buffer.Len()) {
+		t.Fatalf("Expecting %v bytes, got %v %v", buffer.Len(), n, err)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.LoadReader(&buffer); err != nil || loaded.Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v %v", err, loaded.Name)
+	}
+	buffer.Reset()
+	if err := c.Encode(&buffer, configuration.WithFormat(configuration.YAML)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	loaded = &configuration.Configuration{}
+	if err := loaded.LoadReader(&buffer, configuration.WithFormat(configuration.YAML)); err != nil || loaded.Task[0].Name != "ipsum" {
+		t.Errorf("Expecting ipsum, got %v %v", err, loaded.Task)
+	}
+	if err := loaded.LoadReader(strings.NewReader("{"), configuration.WithFormat("unknown")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
@@ -0,0 +1,120 @@
+package configurationtest
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+// GenOptions contains the bounds of the configurations Generate produces
+type GenOptions struct {
+	MaxTasks   int
+	MaxScripts int
+	MaxFiles   int
+	Invalid    bool
+}
+
+// generatedTypes contains the file types and line comments Generate draws File definitions from
+var generatedTypes = []struct {
+	fileType string
+	line     string
+}{
+	{"go", "//"},
+	{"js", "//"},
+	{"ts", "//"},
+	{"java", "//"},
+	{"c", "//"},
+	{"py", "#"},
+	{"rb", "#"},
+	{"sh", "#"},
+}
+
+// generatedDirectories contains the directories Generate draws include and exclude patterns from
+var generatedDirectories = []string{"**", "src/**", "lib/**", "cmd/**", "internal/**"}
+
+// Generate returns a random Configuration that passes Validate, or, with Invalid, fails Validate with at least one error; bounds default to 1 when zero
+func Generate(r *rand.Rand, opts GenOptions) *configuration.Configuration {
+	c := &configuration.Configuration{
+		Name:        fmt.Sprintf("generated-%v", r.Intn(1000)),
+		Description: "Generated configuration",
+		Version:     fmt.Sprintf("%v.%v.%v", r.Intn(10), r.Intn(10), r.Intn(10)),
+	}
+	files := 1 + r.Intn(bound(opts.MaxFiles, len(generatedTypes)))
+	var types []string
+	for _, i := range r.Perm(len(generatedTypes))[:files] {
+		generated := generatedTypes[i]
+		types = append(types, generated.fileType)
+		c.File = append(c.File, &configuration.File{
+			Type: []string{generated.fileType},
+			Parse: &configuration.Parse{
+				Comment: &core.Comment{
+					Line: generated.line,
+				},
+				Source: r.Intn(2) == 0,
+			},
+		})
+	}
+	tasks := 1 + r.Intn(bound(opts.MaxTasks, 0))
+	for i := 0; i < tasks; i++ {
+		task := &configuration.Task{
+			Name: fmt.Sprintf("task%v", i),
+			Path: &configuration.Path{},
+		}
+		for j := 0; j < 1+r.Intn(3); j++ {
+			directory := generatedDirectories[r.Intn(len(generatedDirectories))]
+			task.Path.Include = append(task.Path.Include, directory+"/*."+types[r.Intn(len(types))])
+		}
+		if r.Intn(2) == 0 {
+			task.Path.Exclude = []string{generatedDirectories[1+r.Intn(len(generatedDirectories)-1)]}
+		}
+		c.Task = append(c.Task, task)
+	}
+	scripts := r.Intn(bound(opts.MaxScripts, 0) + 1)
+	for i := 0; i < scripts; i++ {
+		script := &configuration.Script{
+			Name: fmt.Sprintf("script%v", i),
+		}
+		for _, j := range r.Perm(tasks)[:1+r.Intn(tasks)] {
+			script.Task = append(script.Task, c.Task[j].Name)
+		}
+		c.Script = append(c.Script, script)
+	}
+	if opts.Invalid {
+		invalidate(r, c)
+	}
+	return c
+}
+
+// bound returns n when positive, otherwise 1, capped at limit when limit is positive
+func bound(n int, limit int) int {
+	if n < 1 {
+		n = 1
+	}
+	if limit > 0 && n > limit {
+		n = limit
+	}
+	return n
+}
+
+// invalidate introduces one random defect Validate reports into the Configuration
+func invalidate(r *rand.Rand, c *configuration.Configuration) {
+	task := c.Task[r.Intn(len(c.Task))]
+	file := c.File[r.Intn(len(c.File))]
+	switch r.Intn(5) {
+	case 0:
+		task.Name = ""
+	case 1:
+		task.Path.Include = append(task.Path.Include, "")
+	case 2:
+		task.Path = nil
+	case 3:
+		file.Parse = nil
+	case 4:
+		c.Script = append(c.Script, &configuration.Script{
+			Name: "unknown",
+			Task: []string{"unknown"},
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package configurationtest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/emits-io/configuration/configurationtest"
+)
+
+func TestGenerate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		opts := configurationtest.GenOptions{
+			MaxTasks:   5,
+			MaxScripts: 3,
+			MaxFiles:   4,
+		}
+		c := configurationtest.Generate(r, opts)
+		if errs := c.Validate(); len(errs) != 0 {
+			t.Fatalf("Expecting valid configuration, got %v", errs)
+		}
+		if len(c.Task) > 5 || len(c.Script) > 3 || len(c.File) > 4 {
+			t.Fatalf("Expecting bounds respected, got %v %v %v", len(c.Task), len(c.Script), len(c.File))
+		}
+		opts.Invalid = true
+		if errs := configurationtest.Generate(r, opts).Validate(); len(errs) == 0 {
+			t.Fatalf("Expecting invalid configuration, got no errors")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package configurationtest provides a harness for testing layered configurations against an in-memory project tree
+package configurationtest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+)
+
+// Harness contains an in-memory project tree and the test reporting failures
+type Harness struct {
+	t  testing.TB
+	FS fstest.MapFS
+}
+
+// New returns a Harness with an empty project tree
+func New(t testing.TB) *Harness {
+	return &Harness{
+		t:  t,
+		FS: fstest.MapFS{},
+	}
+}
+
+// File adds a project file with the content
+func (h *Harness) File(name string, content string) *Harness {
+	h.FS[name] = &fstest.MapFile{Data: []byte(content), Mode: 0644}
+	return h
+}
+
+// Files adds an empty project file for every name
+func (h *Harness) Files(names ...string) *Harness {
+	for _, name := range names {
+		h.File(name, "")
+	}
+	return h
+}
+
+// Layer adds a configuration file holding the JSON encoding of Configuration
+func (h *Harness) Layer(name string, c *configuration.Configuration) *Harness {
+	h.t.Helper()
+	data, err := json.Marshal(c)
+	if err != nil {
+		h.t.Fatalf("could not encode `%s` layer: %v", name, err)
+	}
+	return h.File(name, string(data))
+}
+
+// Discover returns the configuration file Load would select in the project root
+func (h *Harness) Discover() string {
+	return configuration.ConfigFileFS(h.FS)
+}
+
+// Compose merges the named layers in order, or the discovered configuration file when none are named, and fails the test on any load error
+func (h *Harness) Compose(names ...string) (*configuration.Configuration, *configuration.Report) {
+	h.t.Helper()
+	if len(names) == 0 {
+		names = []string{h.Discover()}
+	}
+	var sources []configuration.Source
+	for _, name := range names {
+		sources = append(sources, configuration.FSSource(h.FS, name))
+	}
+	c, report, err := configuration.Compose(sources...)
+	if err != nil {
+		h.t.Fatalf("could not compose %v: %v", names, err)
+	}
+	return c, report
+}
+
+// Plan resolves the named Script against the project tree and fails the test on any error
+func (h *Harness) Plan(c *configuration.Configuration, script string) *configuration.Plan {
+	h.t.Helper()
+	plan, err := c.PlanFS(script, h.FS)
+	if err != nil {
+		h.t.Fatalf("could not plan `%s` script: %v", script, err)
+	}
+	return plan
+}
+
+// AssertValid fails the test if the Report holds a load or validation error
+func (h *Harness) AssertValid(report *configuration.Report) {
+	h.t.Helper()
+	if !report.Valid() {
+		h.t.Errorf("Expecting valid configuration, got %v %v", report.LoadError, report.Errors)
+	}
+}
+
+// AssertTask fails the test unless the named Task, with Extends resolved, has exactly the include and exclude patterns
+func (h *Harness) AssertTask(c *configuration.Configuration, name string, include []string, exclude []string) {
+	h.t.Helper()
+	task, err := c.FlattenTask(name)
+	if err != nil {
+		h.t.Errorf("Expecting `%s` task, got %v", name, err)
+		return
+	}
+	var path configuration.Path
+	if task.Path != nil {
+		path = *task.Path
+	}
+	if !reflect.DeepEqual(path.Include, include) || !reflect.DeepEqual(path.Exclude, exclude) {
+		h.t.Errorf("Expecting `%s` task include %v exclude %v, got include %v exclude %v", name, include, exclude, path.Include, path.Exclude)
+	}
+}
+
+// AssertFiles fails the test unless the Plan step of the named Task resolves exactly the files, in order
+func (h *Harness) AssertFiles(plan *configuration.Plan, task string, files ...string) {
+	h.t.Helper()
+	for _, step := range plan.Step {
+		if step.Task != task {
+			continue
+		}
+		var paths []string
+		for _, file := range step.File {
+			paths = append(paths, file.Path)
+		}
+		if !reflect.DeepEqual(paths, files) {
+			h.t.Errorf("Expecting `%s` task files %v, got %v", task, files, paths)
+		}
+		return
+	}
+	h.t.Errorf("Expecting `%s` task step, got none", task)
+}
@@ -0,0 +1,61 @@
+package configurationtest_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/configuration/configurationtest"
+	"github.com/emits-io/core"
+)
+
+func TestHarness(t *testing.T) {
+	h := configurationtest.New(t).
+		Files("main.go", "cmd/run.go", "vendor/lib/lib.go", "readme.md").
+		Layer("emits.json", &configuration.Configuration{
+			Task: []*configuration.Task{
+				{
+					Name: "base",
+					Path: &configuration.Path{
+						Include: []string{"**/*.go"},
+						Exclude: []string{"vendor/**"},
+					},
+				},
+			},
+			File: []*configuration.File{
+				{
+					Type: []string{"go"},
+					Parse: &configuration.Parse{
+						Comment: &core.Comment{
+							Line: "//",
+						},
+					},
+				},
+			},
+		}).
+		Layer("local.json", &configuration.Configuration{
+			Task: []*configuration.Task{
+				{
+					Name:    "cmd",
+					Extends: "base",
+					Path: &configuration.Path{
+						Include: []string{"cmd/**/*.go"},
+					},
+				},
+			},
+			Script: []*configuration.Script{
+				{
+					Name: "build",
+					Task: []string{"base", "cmd"},
+				},
+			},
+		})
+	if h.Discover() != configuration.ConfigFile {
+		t.Errorf("Expecting %v, got %v", configuration.ConfigFile, h.Discover())
+	}
+	c, report := h.Compose("emits.json", "local.json")
+	h.AssertValid(report)
+	h.AssertTask(c, "cmd", []string{"cmd/**/*.go"}, []string{"vendor/**"})
+	plan := h.Plan(c, "build")
+	h.AssertFiles(plan, "base", "cmd/run.go", "main.go")
+	h.AssertFiles(plan, "cmd", "cmd/run.go")
+}
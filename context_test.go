@@ -0,0 +1,85 @@
+package configuration_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadFromContext(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"extends": "`+server.URL+`/base.json"}`), 0644)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := (&configuration.Configuration{}).LoadFromContext(ctx, dir); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expecting context.DeadlineExceeded, got %v", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := (&configuration.Configuration{}).LoadFromContext(ctx, dir); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expecting context.Canceled, got %v", err)
+	}
+}
+
+func TestConfiguration_ValidateContext(t *testing.T) {
+	c := &configuration.Configuration{}
+	if errs := c.ValidateContext(context.Background()); len(errs) != len(c.Validate()) {
+		t.Errorf("Expecting Validate errors, got %v", errs)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := c.ValidateContext(ctx)
+	if len(errs) != 1 || configuration.ErrorCode(errs[0]) != configuration.CodeIncomplete {
+		t.Errorf("Expecting incomplete validation, got %v", errs)
+	}
+}
+
+func TestConfiguration_LoadFromContext_Options(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"name": "lorem"}`), 0644)
+	options := make([]configuration.Option, 1, 2)
+	options[0] = configuration.WithTolerant()
+	if err := (&configuration.Configuration{}).LoadFromContext(context.Background(), dir, options...); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if options[:2][1] != nil {
+		t.Errorf("Expecting the options of the caller untouched, got %v", options[:2])
+	}
+}
+
+func TestConfiguration_ScanContext(t *testing.T) {
+	c := &configuration.Configuration{
+		Task:   []*configuration.Task{{Name: "docs", Path: &configuration.Path{Include: []string{"**/*.go"}}}},
+		Script: []*configuration.Script{{Name: "build", Task: []string{"docs"}}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Task[0].FilesContext(ctx, "."); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expecting context.Canceled from FilesContext, got %v", err)
+	}
+	if _, err := c.PlanContext(ctx, "build"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expecting context.Canceled from PlanContext, got %v", err)
+	}
+	if _, err := c.LockFSContext(ctx, os.DirFS(".")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expecting context.Canceled from LockFSContext, got %v", err)
+	}
+	if files, err := c.Task[0].FilesContext(context.Background(), "."); err != nil || len(files) == 0 {
+		t.Errorf("Expecting files, got %v %v", files, err)
+	}
+}
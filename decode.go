@@ -0,0 +1,74 @@
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DecodeError contains a failure to decode a configuration document and the one based line and column it occurred at
+type DecodeError struct {
+	Path    string
+	Line    int
+	Column  int
+	Snippet string
+	Err     error
+}
+
+// Error returns the location, cause and snippet of DecodeError
+func (e *DecodeError) Error() string {
+	location := fmt.Sprintf("%v:%v", e.Line, e.Column)
+	if len(e.Path) > 0 {
+		location = e.Path + ":" + location
+	}
+	return fmt.Sprintf("%s: %v\n%s", location, e.Err, e.Snippet)
+}
+
+// Unwrap returns the underlying decoder error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns the error as a DecodeError if the decoder reported the byte offset it failed at, otherwise the error unchanged
+func decodeError(document []byte, err error) error {
+	var offset int64 = -1
+	var syntax *json.SyntaxError
+	var unmarshal *json.UnmarshalTypeError
+	if errors.As(err, &syntax) {
+		offset = syntax.Offset
+	} else if errors.As(err, &unmarshal) {
+		offset = unmarshal.Offset
+	}
+	if offset < 0 {
+		return err
+	}
+	if offset > int64(len(document)) {
+		offset = int64(len(document))
+	}
+	start := strings.LastIndexByte(string(document[:offset]), '\n') + 1
+	end := strings.IndexByte(string(document[offset:]), '\n')
+	if end < 0 {
+		end = len(document)
+	} else {
+		end += int(offset)
+	}
+	line := strings.Count(string(document[:offset]), "\n") + 1
+	column := int(offset) - start
+	if column < 1 {
+		column = 1
+	}
+	text := strings.TrimRight(string(document[start:end]), "\r")
+	prefix := text
+	if column-1 < len(text) {
+		prefix = text[:column-1]
+	}
+	caret := strings.Repeat(" ", utf8.RuneCountInString(prefix)) + "^"
+	return &DecodeError{
+		Line:    line,
+		Column:  column,
+		Snippet: fmt.Sprintf("%5d | %s\n      | %s", line, strings.ReplaceAll(text, "\t", " "), caret),
+		Err:     err,
+	}
+}
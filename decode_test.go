@@ -0,0 +1,32 @@
+package configuration_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestDecodeError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "emits.json")
+	os.WriteFile(path, []byte("{\n\t\"name\": \"lorem\",\n\t\"task\": [}\n}"), 0644)
+	c := &configuration.Configuration{}
+	err := c.LoadFrom(path)
+	var e *configuration.DecodeError
+	if !errors.As(err, &e) {
+		t.Fatalf("Expecting DecodeError, got %v", err)
+	}
+	if e.Path != path || e.Line != 3 || e.Column != 11 {
+		t.Errorf("Expecting %v:3:11, got %v:%v:%v", path, e.Path, e.Line, e.Column)
+	}
+	if !strings.Contains(e.Snippet, "\"task\": [}") || !strings.HasSuffix(e.Snippet, "          ^") {
+		t.Errorf("Expecting snippet with caret, got\n%v", e.Snippet)
+	}
+	err = c.LoadReader(strings.NewReader(`{"name": 1}`))
+	if !errors.As(err, &e) || e.Line != 1 {
+		t.Errorf("Expecting DecodeError on line 1, got %v", err)
+	}
+}
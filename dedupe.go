@@ -0,0 +1,49 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// ScriptFiles returns the union of the files resolved by every task of the named Script in the current directory, sorted and without duplicates
+func (c *Configuration) ScriptFiles(script string) ([]string, error) {
+	return c.ScriptFilesFS(script, os.DirFS("."))
+}
+
+// ScriptFilesFS returns the union of the files resolved by every enabled task of the named Script in the file system, sorted and without duplicates
+func (c *Configuration) ScriptFilesFS(script string, fsys fs.FS) ([]string, error) {
+	s, err := c.enabledScript(script)
+	if err != nil {
+		return nil, err
+	}
+	var union []string
+	seen := map[string]bool{}
+	for _, name := range s.Task {
+		t := c.FindTask(name)
+		if t == nil {
+			return nil, fmt.Errorf("`%s` script referencing unknown `%s` task definition", s.Name, name)
+		}
+		task, err := c.flatten(t, nil)
+		if err != nil {
+			return nil, err
+		}
+		if task.Disabled || task.Path == nil {
+			continue
+		}
+		files, err := resolveFilesFS(context.Background(), fsys, task.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !seen[file] {
+				seen[file] = true
+				union = append(union, file)
+			}
+		}
+	}
+	sort.Strings(union)
+	return union, nil
+}
@@ -0,0 +1,61 @@
+package configuration_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestScript_Dedupe(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go":    {},
+		"cmd/run.go": {},
+	}
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "all",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+				},
+			},
+			{
+				Name: "cmd",
+				Path: &configuration.Path{
+					Include: []string{"cmd/*.go"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"all", "cmd"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+	files, err := c.ScriptFilesFS("build", fsys)
+	if err != nil || len(files) != 2 || files[0] != "cmd/run.go" || files[1] != "main.go" {
+		t.Errorf("Expecting cmd/run.go and main.go, got %v %v", files, err)
+	}
+	plan, _ := c.PlanFS("build", fsys)
+	if len(plan.Step[1].File) != 1 {
+		t.Errorf("Expecting 1 file, got %v", plan.Step[1].File)
+	}
+	c.Script[0].Dedupe = true
+	plan, _ = c.PlanFS("build", fsys)
+	if len(plan.Step[0].File) != 2 || len(plan.Step[1].File) != 0 {
+		t.Errorf("Expecting files planned once, got %v %v", plan.Step[0].File, plan.Step[1].File)
+	}
+}
@@ -0,0 +1,204 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Definitions contains abstract Task and File definitions that are never processed directly; they exist solely to be extended
+type Definitions struct {
+	Task []*Task `json:"task,omitempty"`
+	File []*File `json:"file,omitempty"`
+}
+
+// FindTask returns the abstract Task if found or nil if not found; used to resolve Task Extends references
+func (d *Definitions) FindTask(name string) *Task {
+	if d == nil {
+		return nil
+	}
+	for _, t := range d.Task {
+		if t == nil {
+			continue
+		}
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// FindFile returns the abstract File if found or nil if not found; used to resolve File Extends references
+func (d *Definitions) FindFile(name string) *File {
+	if d == nil {
+		return nil
+	}
+	for _, f := range d.File {
+		if f == nil {
+			continue
+		}
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Validate returns all known errors of the abstract definitions; abstract definitions may be incomplete, only naming and references are validated
+func (d *Definitions) Validate(c *Configuration) []error {
+	var errors []error
+	if d == nil {
+		return errors
+	}
+	var seenTask []string
+	for i, t := range d.Task {
+		if t == nil {
+			errors = append(errors, newError(CodeMissing, pointer("definitions", "task", i), "definition task at index `%v` is null", i))
+			continue
+		}
+		if len(t.Name) == 0 {
+			errors = append(errors, newError(CodeMissing, pointer("definitions", "task", i, "name"), "definition task at index `%v` missing name definition", i))
+			continue
+		}
+		if contains(seenTask, t.Name) {
+			errors = append(errors, newError(CodeDuplicate, pointer("definitions", "task", i, "name"), "`%s` definition task is defined more than once", t.Name))
+		}
+		seenTask = append(seenTask, t.Name)
+		if c.FindTask(t.Name) != nil {
+			errors = append(errors, newError(CodeConflict, pointer("definitions", "task", i, "name"), "`%s` definition task conflicts with `%s` task definition", t.Name, t.Name))
+		}
+		if len(t.Extends) > 0 {
+			if _, err := c.flatten(t, nil); err != nil {
+				errors = append(errors, wrapError(CodeExtends, pointer("definitions", "task", i, "extends"), err))
+			}
+		}
+	}
+	var seenFile []string
+	for i, f := range d.File {
+		if f == nil {
+			errors = append(errors, newError(CodeMissing, pointer("definitions", "file", i), "definition file at index `%v` is null", i))
+			continue
+		}
+		if len(f.Name) == 0 {
+			errors = append(errors, newError(CodeMissing, pointer("definitions", "file", i, "name"), "definition file at index `%v` missing name definition", i))
+			continue
+		}
+		if contains(seenFile, f.Name) {
+			errors = append(errors, newError(CodeDuplicate, pointer("definitions", "file", i, "name"), "`%s` definition file is defined more than once", f.Name))
+		}
+		seenFile = append(seenFile, f.Name)
+		if len(f.Extends) > 0 {
+			if _, err := c.flattenFile(f, nil); err != nil {
+				errors = append(errors, wrapError(CodeExtends, pointer("definitions", "file", i, "extends"), err))
+			}
+		}
+	}
+	return errors
+}
+
+// ValidateFileExtends returns all unknown and cyclic File Extends references
+func (c *Configuration) ValidateFileExtends() []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	var errors []error
+	for i, f := range c.File {
+		if f == nil {
+			continue
+		}
+		if len(f.Extends) == 0 {
+			continue
+		}
+		if _, err := c.flattenFile(f, nil); err != nil {
+			errors = append(errors, wrapError(CodeExtends, pointer("file", i, "extends"), err))
+		}
+	}
+	return errors
+}
+
+// FlattenFiles returns a copy of every File with Extends resolved; returns an error on unknown or cyclic Extends
+func (c *Configuration) FlattenFiles() ([]*File, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	var files []*File
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file, err := c.flattenFile(f, nil)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// resolveFile returns the flattened File when Extends resolves, otherwise the File as defined; used by Validate
+func (c *Configuration) resolveFile(f *File) *File {
+	file, err := c.flattenFile(f, nil)
+	if err != nil {
+		return f
+	}
+	return file
+}
+
+// flattenFile returns a copy of File merged over the abstract File it extends; chain tracks visited names for cycle detection
+func (c *Configuration) flattenFile(f *File, chain []string) (*File, error) {
+	if len(f.Extends) == 0 {
+		return f.inherit(nil), nil
+	}
+	name := f.Name
+	if len(name) == 0 {
+		name = strings.Join(f.Type, ",")
+	}
+	chain = append(chain, name)
+	if contains(chain, f.Extends) {
+		return nil, fmt.Errorf("`%s` file extends cycle `%s`", chain[0], strings.Join(append(chain, f.Extends), " > "))
+	}
+	parent := c.Definitions.FindFile(f.Extends)
+	if parent == nil {
+		return nil, fmt.Errorf("`%s` file extends unknown `%s` definition file", name, f.Extends)
+	}
+	base, err := c.flattenFile(parent, chain)
+	if err != nil {
+		return nil, err
+	}
+	return f.inherit(base), nil
+}
+
+// inherit returns a copy of File with unset options taken from the parent File
+func (f *File) inherit(parent *File) *File {
+	file := *f
+	file.Type = copyStrings(f.Type)
+	if parent == nil {
+		return &file
+	}
+	file.Extends = ""
+	if len(file.Type) == 0 {
+		file.Type = copyStrings(parent.Type)
+	}
+	if file.Parse == nil {
+		file.Parse = parent.Parse
+	}
+	if file.Modify == nil {
+		file.Modify = parent.Modify
+	}
+	if file.Audit == nil {
+		file.Audit = parent.Audit
+	}
+	if len(file.Output) == 0 {
+		file.Output = parent.Output
+	}
+	return &file
+}
+
+// contains returns true if the value is found within the slice
+func contains(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
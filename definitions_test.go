@@ -0,0 +1,86 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestDefinitions_Validate(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name:    "docs",
+				Extends: "source",
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"source"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type:    []string{"go"},
+				Extends: "c-style",
+			},
+		},
+		Definitions: &configuration.Definitions{
+			Task: []*configuration.Task{
+				{
+					Name: "source",
+					Path: &configuration.Path{
+						Include: []string{"src/*"},
+					},
+				},
+			},
+			File: []*configuration.File{
+				{
+					Name: "c-style",
+					Parse: &configuration.Parse{
+						Comment: &core.Comment{
+							Line: "//",
+						},
+					},
+				},
+			},
+		},
+	}
+	err := c.Validate()
+	if len(err) != 1 {
+		t.Errorf("Expecting only the script reference to an abstract task to fail, got %v", err)
+	}
+	files, errFlatten := c.FlattenFiles()
+	if errFlatten != nil {
+		t.Fatalf("Expecting nil, got %v", errFlatten)
+	}
+	if files[0].Parse == nil || files[0].Parse.Comment.Line != "//" {
+		t.Errorf("Expecting inherited parse definition, got %v", files[0].Parse)
+	}
+	c.Definitions.Task = append(c.Definitions.Task, &configuration.Task{}, &configuration.Task{Name: "source"}, &configuration.Task{Name: "docs"})
+	c.Definitions.File = append(c.Definitions.File, &configuration.File{Name: "loop", Extends: "loop"})
+	errDefinitions := c.Definitions.Validate(c)
+	if len(errDefinitions) != 4 {
+		t.Errorf("Expecting 4 errors, got %v", errDefinitions)
+	}
+}
+
+func TestConfiguration_ValidateFileExtends(t *testing.T) {
+	c := &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type:    []string{"go"},
+				Extends: "unknown",
+			},
+		},
+	}
+	err := c.ValidateFileExtends()
+	if len(err) != 1 {
+		t.Errorf("Expecting 1 error, got %v", err)
+	}
+	if c.Definitions.FindFile("unknown") != nil {
+		t.Errorf("Expecting nil, got file")
+	}
+}
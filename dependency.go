@@ -0,0 +1,170 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TaskDependencies returns, for every enabled Task, the names of the enabled tasks whose outputs may produce one of its inputs, in declaration order
+func (c *Configuration) TaskDependencies() map[string][]string {
+	if c == nil {
+		return nil
+	}
+	tasks := c.enabledTasks()
+	dependencies := map[string][]string{}
+	for _, consumer := range tasks {
+		dependencies[consumer.Name] = []string{}
+		for _, producer := range tasks {
+			if producer.Name != consumer.Name && produces(producer.Outputs, consumer.Inputs) {
+				dependencies[consumer.Name] = append(dependencies[consumer.Name], producer.Name)
+			}
+		}
+	}
+	return dependencies
+}
+
+// TaskOrder returns the named tasks, or every enabled Task when none are named, ordered so every producer runs before its consumers, otherwise in declaration order; producers that are not named are not added; returns an error on a dependency cycle
+func (c *Configuration) TaskOrder(names ...string) ([]string, error) {
+	dependencies := c.TaskDependencies()
+	if len(names) == 0 {
+		for _, task := range c.enabledTasks() {
+			names = append(names, task.Name)
+		}
+	}
+	for _, name := range names {
+		if _, ok := dependencies[name]; !ok {
+			return nil, fmt.Errorf("unknown `%s` task definition", name)
+		}
+	}
+	var order []string
+	state := map[string]int{}
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		chain = append(chain, name)
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("`%s` task dependency cycle `%s`", chain[0], strings.Join(chain, " > "))
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, dependency := range dependencies[name] {
+			if contains(names, dependency) {
+				if err := visit(dependency, chain); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// ValidateTaskDependencies returns an error for every dependency cycle between tasks, reported on its first declared Task; the dependencies are inferred once and searched for cycles in a single walk
+func (c *Configuration) ValidateTaskDependencies() []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	dependencies := c.TaskDependencies()
+	declared := map[string]int{}
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if _, ok := declared[t.Name]; !ok {
+			declared[t.Name] = i
+		}
+	}
+	var cycles [][]string
+	state := map[string]int{}
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = 1
+		stack = append(stack, name)
+		for _, dependency := range dependencies[name] {
+			switch state[dependency] {
+			case 0:
+				visit(dependency)
+			case 1:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dependency {
+						cycles = append(cycles, append([]string{}, stack[i:]...))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = 2
+	}
+	for _, task := range c.enabledTasks() {
+		if state[task.Name] == 0 {
+			visit(task.Name)
+		}
+	}
+	var reports []*ValidationError
+	cycled := map[string]bool{}
+	for _, cycle := range cycles {
+		first, reported := 0, true
+		for i, name := range cycle {
+			if declared[name] < declared[cycle[first]] {
+				first = i
+			}
+			reported = reported && cycled[name]
+		}
+		if reported {
+			continue
+		}
+		chain := append(append(append([]string{}, cycle[first:]...), cycle[:first]...), cycle[first])
+		for _, name := range cycle {
+			cycled[name] = true
+		}
+		reports = append(reports, newError(CodeCycle, pointer("task", declared[chain[0]], "inputs"), "`%s` task dependency cycle `%s`", chain[0], strings.Join(chain, " > ")))
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return declared[reports[i].Args[0].(string)] < declared[reports[j].Args[0].(string)]
+	})
+	var errors []error
+	for _, report := range reports {
+		errors = append(errors, report)
+	}
+	return errors
+}
+
+// enabledTasks returns every enabled Task resolved through Extends, in declaration order
+func (c *Configuration) enabledTasks() []*Task {
+	if c == nil {
+		return nil
+	}
+	var tasks []*Task
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if task := c.resolve(t); !task.Disabled {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// produces returns true if any of the output patterns may match a file matched by any of the input patterns
+func produces(outputs []string, inputs []string) bool {
+	for _, output := range outputs {
+		for _, input := range inputs {
+			if intersects(output, input) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package configuration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_TaskOrder(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{Name: "publish", Inputs: []string{"dist/**/*.html"}},
+			{Name: "render", Inputs: []string{"docs/*.md"}, Outputs: []string{"dist/**/*.html"}},
+			{Name: "extract", Inputs: []string{"src/**/*.go"}, Outputs: []string{"docs/*.md"}},
+			{Name: "lint"},
+		},
+	}
+	dependencies := c.TaskDependencies()
+	if !reflect.DeepEqual(dependencies["publish"], []string{"render"}) || len(dependencies["extract"]) != 0 {
+		t.Errorf("Expecting inferred dependencies, got %v", dependencies)
+	}
+	order, err := c.TaskOrder()
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if expected := []string{"extract", "render", "publish", "lint"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expecting %v, got %v", expected, order)
+	}
+	order, err = c.TaskOrder("publish", "extract")
+	if err != nil || !reflect.DeepEqual(order, []string{"publish", "extract"}) {
+		t.Errorf("Expecting [publish extract], got %v, %v", order, err)
+	}
+	if _, err := c.TaskOrder("missing"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if errors := c.ValidateTaskDependencies(); len(errors) != 0 {
+		t.Errorf("Expecting no errors, got %v", errors)
+	}
+	c.Task[2].Inputs = append(c.Task[2].Inputs, "dist/index.html")
+	if _, err := c.TaskOrder(); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	errors := c.ValidateTaskDependencies()
+	if len(errors) != 1 || errors[0].Error() != "`render` task dependency cycle `render > extract > render`" {
+		t.Errorf("Expecting a single cycle error, got %v", errors)
+	}
+}
+
+func TestConfiguration_ValidateTaskDependencies(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{Name: "format", Inputs: []string{"lint.out"}, Outputs: []string{"format.out"}},
+			{Name: "publish", Inputs: []string{"dist/*.html"}, Outputs: []string{"docs/*.md"}},
+			{Name: "lint", Inputs: []string{"format.out"}, Outputs: []string{"lint.out"}},
+			{Name: "render", Inputs: []string{"docs/*.md"}, Outputs: []string{"dist/*.html"}},
+		},
+	}
+	errors := c.ValidateTaskDependencies()
+	if len(errors) != 2 {
+		t.Fatalf("Expecting 2 errors, got %v", errors)
+	}
+	if errors[0].Error() != "`format` task dependency cycle `format > lint > format`" {
+		t.Errorf("Expecting format cycle, got %v", errors[0])
+	}
+	if errors[1].Error() != "`publish` task dependency cycle `publish > render > publish`" {
+		t.Errorf("Expecting publish cycle, got %v", errors[1])
+	}
+}
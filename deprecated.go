@@ -0,0 +1,63 @@
+package configuration
+
+// DeprecatedTasks returns every Task with a Deprecated message
+func (c *Configuration) DeprecatedTasks() []*Task {
+	if c == nil {
+		return nil
+	}
+	var tasks []*Task
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if len(t.Deprecated) > 0 {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// DeprecatedScripts returns every Script with a Deprecated message
+func (c *Configuration) DeprecatedScripts() []*Script {
+	if c == nil {
+		return nil
+	}
+	var scripts []*Script
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if len(s.Deprecated) > 0 {
+			scripts = append(scripts, s)
+		}
+	}
+	return scripts
+}
+
+// lintDeprecations returns a warning for every deprecated Task and Script, and for every Script referencing a deprecated Task unless the Script is itself deprecated
+func (c *Configuration) lintDeprecations() []error {
+	var warnings []error
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if len(t.Deprecated) > 0 {
+			warnings = append(warnings, newWarning(CodeDeprecated, pointer("task", i, "deprecated"), "`%s` task is deprecated: %s", t.Name, t.Deprecated))
+		}
+	}
+	for i, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if len(s.Deprecated) > 0 {
+			warnings = append(warnings, newWarning(CodeDeprecated, pointer("script", i, "deprecated"), "`%s` script is deprecated: %s", s.Name, s.Deprecated))
+			continue
+		}
+		for j, name := range s.Task {
+			if t := c.FindTask(name); t != nil && len(t.Deprecated) > 0 {
+				warnings = append(warnings, newWarning(CodeDeprecated, pointer("script", i, "task", j), "`%s` script references deprecated `%s` task: %s", s.Name, name, t.Deprecated))
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Deprecated(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name:       "lorem",
+				Deprecated: "use `ipsum` instead",
+			},
+			{
+				Name: "ipsum",
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"ipsum", "lorem"},
+			},
+			{
+				Name:       "legacy",
+				Task:       []string{"lorem"},
+				Deprecated: "use `build` instead",
+			},
+		},
+	}
+	if tasks := c.DeprecatedTasks(); len(tasks) != 1 || tasks[0].Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v", tasks)
+	}
+	if scripts := c.DeprecatedScripts(); len(scripts) != 1 || scripts[0].Name != "legacy" {
+		t.Errorf("Expecting legacy, got %v", scripts)
+	}
+	var pointers []string
+	for _, warning := range c.Lint() {
+		if configuration.ErrorCode(warning) == configuration.CodeDeprecated {
+			pointers = append(pointers, configuration.Pointer(warning))
+		}
+	}
+	expected := []string{"/task/0/deprecated", "/script/0/task/1", "/script/1/deprecated"}
+	if len(pointers) != len(expected) {
+		t.Fatalf("Expecting %v, got %v", expected, pointers)
+	}
+	for i := range expected {
+		if pointers[i] != expected[i] {
+			t.Errorf("Expecting %v, got %v", expected[i], pointers[i])
+		}
+	}
+}
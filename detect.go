@@ -0,0 +1,163 @@
+package configuration
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/emits-io/core"
+)
+
+// InitSuggestions contains the project kinds, tasks, file definitions and excludes suggested by Detect
+type InitSuggestions struct {
+	Project []string
+	Task    []*Task
+	File    []*File
+	Exclude []string
+}
+
+// language contains the file extensions and comment syntax of a known language
+type language struct {
+	name      string
+	extension []string
+	comment   *core.Comment
+}
+
+// languages contains every language Detect is able to suggest a File definition for, and the built-in presets of ApplyPreset
+var languages = []*language{
+	{"go", []string{"go"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"javascript", []string{"js", "jsx", "mjs", "cjs"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"typescript", []string{"ts", "tsx", "mts", "cts"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"python", []string{"py"}, &core.Comment{Line: "#", Block: &core.CommentBlock{Start: "\"\"\"", End: "\"\"\""}}},
+	{"ruby", []string{"rb"}, &core.Comment{Line: "#", Block: &core.CommentBlock{Start: "=begin", End: "=end"}}},
+	{"c", []string{"c", "h", "cc", "cpp", "cxx", "hpp", "hh"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"rust", []string{"rs"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"shell", []string{"sh", "bash", "zsh"}, &core.Comment{Line: "#"}},
+	{"html", []string{"html", "htm"}, &core.Comment{Block: &core.CommentBlock{Start: "<!--", End: "-->"}}},
+	{"css", []string{"css", "scss", "less"}, &core.Comment{Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"sql", []string{"sql"}, &core.Comment{Line: "--", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"java", []string{"java"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"kotlin", []string{"kt", "kts"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"swift", []string{"swift"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"csharp", []string{"cs"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"php", []string{"php"}, &core.Comment{Line: "//", Block: &core.CommentBlock{Start: "/*", End: "*/"}}},
+	{"yaml", []string{"yaml", "yml"}, &core.Comment{Line: "#"}},
+}
+
+// projects contains the marker files used to identify a project kind and the directories it should exclude
+var projects = []struct {
+	name    string
+	marker  []string
+	exclude []string
+}{
+	{"go", []string{"go.mod"}, []string{"vendor/**"}},
+	{"node", []string{"package.json"}, []string{"node_modules/**", "dist/**"}},
+	{"python", []string{"pyproject.toml", "setup.py", "requirements.txt"}, []string{".venv/**", "**/__pycache__/**"}},
+	{"rust", []string{"Cargo.toml"}, []string{"target/**"}},
+	{"ruby", []string{"Gemfile"}, []string{"vendor/bundle/**"}},
+}
+
+// skipDirectory contains the directory names Detect never descends into
+var skipDirectory = []string{".git", ".hg", ".svn", "node_modules", "vendor", ".venv", "venv", "__pycache__", "target", "dist"}
+
+// findLanguage returns the language for the provided file extension, or nil if unknown
+func findLanguage(extension string) *language {
+	for _, l := range languages {
+		if contains(l.extension, extension) {
+			return l
+		}
+	}
+	return nil
+}
+
+// Detect inspects the project at root (marker files and file extensions present) and returns suggestions used to seed a new Configuration
+func Detect(root string) (*InitSuggestions, error) {
+	suggestions := &InitSuggestions{
+		Exclude: []string{".git/**"},
+	}
+	for _, project := range projects {
+		for _, marker := range project.marker {
+			if _, err := os.Stat(filepath.Join(root, marker)); err == nil {
+				suggestions.Project = append(suggestions.Project, project.name)
+				suggestions.Exclude = append(suggestions.Exclude, project.exclude...)
+				break
+			}
+		}
+	}
+	count := map[string]int{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && contains(skipDirectory, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		extension := strings.TrimPrefix(filepath.Ext(d.Name()), ".")
+		if findLanguage(extension) != nil {
+			count[extension]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	total := map[*language]int{}
+	for extension, n := range count {
+		total[findLanguage(extension)] += n
+	}
+	var detected []*language
+	for l := range total {
+		detected = append(detected, l)
+	}
+	sort.Slice(detected, func(i, j int) bool {
+		if total[detected[i]] != total[detected[j]] {
+			return total[detected[i]] > total[detected[j]]
+		}
+		return detected[i].name < detected[j].name
+	})
+	for _, l := range detected {
+		var types, include []string
+		for _, extension := range l.extension {
+			if count[extension] > 0 {
+				types = append(types, extension)
+				include = append(include, "**/*."+extension)
+			}
+		}
+		suggestions.Task = append(suggestions.Task, &Task{
+			Name: l.name,
+			Path: &Path{
+				Include: include,
+				Exclude: copyStrings(suggestions.Exclude),
+			},
+		})
+		suggestions.File = append(suggestions.File, &File{
+			Type: types,
+			Parse: &Parse{
+				Comment: copyComment(l.comment),
+			},
+		})
+	}
+	return suggestions, nil
+}
+
+// copyComment returns a deep copy of core.Comment
+func copyComment(c *core.Comment) *core.Comment {
+	if c == nil {
+		return nil
+	}
+	comment := &core.Comment{
+		Line: c.Line,
+	}
+	if c.Block != nil {
+		comment.Block = &core.CommentBlock{
+			Start: c.Block.Start,
+			End:   c.Block.End,
+		}
+	}
+	return comment
+}
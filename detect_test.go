@@ -0,0 +1,46 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "cmd"), 0755)
+	os.MkdirAll(filepath.Join(root, "vendor", "lib"), 0755)
+	os.MkdirAll(filepath.Join(root, "web"), 0755)
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644)
+	os.WriteFile(filepath.Join(root, "cmd", "run.go"), []byte("package cmd\n"), 0644)
+	os.WriteFile(filepath.Join(root, "vendor", "lib", "a.go"), []byte("package lib\n"), 0644)
+	os.WriteFile(filepath.Join(root, "vendor", "lib", "b.py"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(root, "web", "app.js"), []byte(""), 0644)
+	os.WriteFile(filepath.Join(root, "readme.md"), []byte(""), 0644)
+	s, err := configuration.Detect(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(s.Project) != 1 || s.Project[0] != "go" {
+		t.Errorf("Expecting go project, got %v", s.Project)
+	}
+	if len(s.File) != 2 || s.File[0].Type[0] != "go" || s.File[1].Type[0] != "js" {
+		t.Fatalf("Expecting go and js file definitions, got %v", s.File)
+	}
+	if s.File[0].Parse.Validate(s.File[0]) != nil {
+		t.Errorf("Expecting valid parse definition, got %v", s.File[0].Parse.Validate(s.File[0]))
+	}
+	if len(s.Task) != 2 || s.Task[0].Path.Include[0] != "**/*.go" {
+		t.Errorf("Expecting go task, got %v", s.Task)
+	}
+	if len(s.Exclude) != 2 || s.Exclude[1] != "vendor/**" {
+		t.Errorf("Expecting vendor exclude, got %v", s.Exclude)
+	}
+	_, err = configuration.Detect(filepath.Join(root, "missing"))
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
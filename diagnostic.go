@@ -0,0 +1,216 @@
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DiagnosticError constant for the Language Server Protocol error severity
+	DiagnosticError = 1
+	// DiagnosticWarning constant for the Language Server Protocol warning severity
+	DiagnosticWarning = 2
+	// DiagnosticSource constant for the source reported on every Diagnostic
+	DiagnosticSource = "emits"
+)
+
+// Position contains a zero based line and UTF-16 character offset, as used by the Language Server Protocol
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range contains the start and end Position of a document region, as used by the Language Server Protocol
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic contains a single finding in the shape of a Language Server Protocol diagnostic
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Code     Code   `json:"code,omitempty"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+	Data     []*Fix `json:"data,omitempty"`
+}
+
+// Diagnostics returns the Report findings as Language Server Protocol diagnostics; ranges resolve from each finding's JSON pointer to its nearest element in the document
+func Diagnostics(report *Report, document []byte) ([]*Diagnostic, error) {
+	var diagnostics []*Diagnostic
+	if report.LoadError != nil {
+		var syntax *json.SyntaxError
+		offset := 0
+		if errors.As(report.LoadError, &syntax) {
+			offset = int(syntax.Offset)
+		}
+		position := offsetPosition(document, offset)
+		return append(diagnostics, &Diagnostic{
+			Range:    Range{Start: position, End: position},
+			Severity: DiagnosticError,
+			Source:   DiagnosticSource,
+			Message:  report.LoadError.Error(),
+		}), nil
+	}
+	positions, err := Positions(document)
+	if err != nil {
+		return nil, err
+	}
+	add := func(findings []error, severity int) {
+		for _, finding := range findings {
+			diagnostics = append(diagnostics, &Diagnostic{
+				Range:    *nearest(positions, Pointer(finding)),
+				Severity: severity,
+				Code:     ErrorCode(finding),
+				Source:   DiagnosticSource,
+				Message:  finding.Error(),
+				Data:     fixes(finding),
+			})
+		}
+	}
+	add(report.Errors, DiagnosticError)
+	add(report.Warnings, DiagnosticWarning)
+	return diagnostics, nil
+}
+
+// fixes returns the Fix list of a ValidationError, or nil for any other error
+func fixes(err error) []*Fix {
+	if e, ok := err.(*ValidationError); ok {
+		return e.Fix
+	}
+	return nil
+}
+
+// Positions returns the Range of every value in the JSON document keyed by JSON pointer; the document root is keyed by ""
+func Positions(document []byte) (map[string]*Range, error) {
+	if !json.Valid(document) {
+		return nil, fmt.Errorf("document is not valid json")
+	}
+	s := &scanner{
+		data:   document,
+		offset: map[string][2]int{},
+	}
+	s.value("")
+	positions := make(map[string]*Range, len(s.offset))
+	for key, offset := range s.offset {
+		positions[key] = &Range{
+			Start: offsetPosition(document, offset[0]),
+			End:   offsetPosition(document, offset[1]),
+		}
+	}
+	return positions, nil
+}
+
+// nearest returns the Range of the JSON pointer, or of its closest existing ancestor
+func nearest(positions map[string]*Range, p string) *Range {
+	for {
+		if r, ok := positions[p]; ok {
+			return r
+		}
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			return &Range{}
+		}
+		p = p[:i]
+	}
+}
+
+// offsetPosition returns the Position of the byte offset within the document
+func offsetPosition(document []byte, offset int) Position {
+	if offset > len(document) {
+		offset = len(document)
+	}
+	position := Position{}
+	start := 0
+	for i := 0; i < offset; i++ {
+		if document[i] == '\n' {
+			position.Line++
+			start = i + 1
+		}
+	}
+	for _, r := range string(document[start:offset]) {
+		position.Character++
+		if utf8.RuneLen(r) == 4 {
+			position.Character++
+		}
+	}
+	return position
+}
+
+// scanner records the byte offsets of every value of a valid JSON document
+type scanner struct {
+	data   []byte
+	i      int
+	offset map[string][2]int
+}
+
+// value scans the value at the current offset and records it under the JSON pointer
+func (s *scanner) value(p string) {
+	s.space()
+	start := s.i
+	switch s.data[s.i] {
+	case '{':
+		s.i++
+		for {
+			s.space()
+			if s.data[s.i] == '}' {
+				s.i++
+				break
+			}
+			if s.data[s.i] == ',' {
+				s.i++
+				s.space()
+			}
+			keyStart := s.i
+			s.text()
+			var key string
+			json.Unmarshal(s.data[keyStart:s.i], &key)
+			s.space()
+			s.i++
+			s.value(p + pointer(key))
+		}
+	case '[':
+		s.i++
+		for index := 0; ; index++ {
+			s.space()
+			if s.data[s.i] == ']' {
+				s.i++
+				break
+			}
+			if s.data[s.i] == ',' {
+				s.i++
+			}
+			s.value(p + pointer(index))
+		}
+	case '"':
+		s.text()
+	default:
+		for s.i < len(s.data) && !strings.ContainsRune(",]} \t\r\n", rune(s.data[s.i])) {
+			s.i++
+		}
+	}
+	s.offset[p] = [2]int{start, s.i}
+}
+
+// text advances past the string at the current offset
+func (s *scanner) text() {
+	s.i++
+	for s.data[s.i] != '"' {
+		if s.data[s.i] == '\\' {
+			s.i++
+		}
+		s.i++
+	}
+	s.i++
+}
+
+// space advances past any whitespace at the current offset
+func (s *scanner) space() {
+	for s.i < len(s.data) && strings.ContainsRune(" \t\r\n", rune(s.data[s.i])) {
+		s.i++
+	}
+}
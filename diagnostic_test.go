@@ -0,0 +1,60 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestPositions(t *testing.T) {
+	document := []byte("{\n\t\"task\": [\n\t\t{\"name\": \"lörem\", \"path\": {\"include\": [\"*\", \"\"]}}\n\t]\n}")
+	positions, err := configuration.Positions(document)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	r := positions["/task/0/path/include/1"]
+	if r == nil {
+		t.Fatalf("Expecting range, got nil")
+	}
+	expected := configuration.Range{
+		Start: configuration.Position{Line: 2, Character: 46},
+		End:   configuration.Position{Line: 2, Character: 48},
+	}
+	if *r != expected {
+		t.Errorf("Expecting %v, got %v", expected, *r)
+	}
+	_, err = configuration.Positions([]byte("{"))
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	document := []byte("{\n\t\"task\": [\n\t\t{\"name\": \"lorem\", \"path\": {\"include\": [\"\", \"**/*\", \"*.go\"]}}\n\t],\n\t\"description\": \"lorem\",\n\t\"version\": \"1.0.0\"\n}")
+	c := &configuration.Configuration{}
+	json.Unmarshal(document, c)
+	report := c.Report()
+	diagnostics, err := configuration.Diagnostics(report, document)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(diagnostics) != 3 {
+		t.Fatalf("Expecting 3 diagnostics, got %v", len(diagnostics))
+	}
+	if diagnostics[0].Range.Start.Line != 0 || diagnostics[0].Severity != configuration.DiagnosticError {
+		t.Errorf("Expecting missing file definition at the document root, got %v", diagnostics[0])
+	}
+	if diagnostics[1].Range.Start != (configuration.Position{Line: 2, Character: 41}) {
+		t.Errorf("Expecting empty include range, got %v", diagnostics[1].Range)
+	}
+	if diagnostics[2].Severity != configuration.DiagnosticWarning || diagnostics[2].Range.Start.Character != 53 {
+		t.Errorf("Expecting shadowed include warning, got %v", diagnostics[2])
+	}
+	report = &configuration.Report{}
+	report.LoadError = json.Unmarshal([]byte("{\n\"task\": }"), c)
+	diagnostics, err = configuration.Diagnostics(report, []byte("{\n\"task\": }"))
+	if err != nil || len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 1 {
+		t.Errorf("Expecting syntax error diagnostic on line 1, got %v %v", diagnostics, err)
+	}
+}
@@ -0,0 +1,191 @@
+package configuration
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Diff returns the changes turning Configuration into other, classified by Impact: fields, tasks, scripts, file types, plugins and vars added, removed or changed; pointers of removed elements refer to Configuration, the others to other
+func (c *Configuration) Diff(other *Configuration) []*Change {
+	if c == nil {
+		c = &Configuration{}
+	}
+	if other == nil {
+		other = &Configuration{}
+	}
+	var changes []*Change
+	change := func(action string, element string, name string, p string) {
+		changes = append(changes, &Change{Action: action, Element: element, Name: name, Pointer: p})
+	}
+	fields := []struct {
+		name   string
+		before string
+		after  string
+	}{
+		{"$schema", c.Schema, other.Schema},
+		{"extends", c.Extends, other.Extends},
+		{"schemaVersion", strconv.Itoa(c.SchemaVersion), strconv.Itoa(other.SchemaVersion)},
+		{"name", c.Name, other.Name},
+		{"description", c.Description, other.Description},
+		{"author", c.Author, other.Author},
+		{"license", c.License, other.License},
+		{"version", c.Version, other.Version},
+	}
+	for _, field := range fields {
+		if field.before != field.after {
+			change(ChangeChanged, "field", field.name, pointer(field.name))
+		}
+	}
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if other.FindTask(t.Name) == nil {
+			change(ChangeRemoved, "task", t.Name, pointer("task", i))
+		}
+	}
+	for i, t := range other.Task {
+		if t == nil {
+			continue
+		}
+		if before := c.FindTask(t.Name); before == nil {
+			change(ChangeAdded, "task", t.Name, pointer("task", i))
+		} else if !reflect.DeepEqual(before, t) {
+			change(ChangeChanged, "task", t.Name, pointer("task", i))
+			if requiresNewParam(before, t) {
+				changes[len(changes)-1].Impact = ImpactBreaking
+			}
+		}
+	}
+	for i, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if other.FindScript(s.Name) == nil {
+			change(ChangeRemoved, "script", s.Name, pointer("script", i))
+		}
+	}
+	for i, s := range other.Script {
+		if s == nil {
+			continue
+		}
+		if before := c.FindScript(s.Name); before == nil {
+			change(ChangeAdded, "script", s.Name, pointer("script", i))
+		} else if !reflect.DeepEqual(before, s) {
+			change(ChangeChanged, "script", s.Name, pointer("script", i))
+		}
+	}
+	for i, f := range c.File {
+		if f == nil {
+			continue
+		}
+		for _, fileType := range f.Type {
+			if other.indexFile(fileType) < 0 {
+				change(ChangeRemoved, "file type", fileType, pointer("file", i))
+			}
+		}
+	}
+	compared := map[[2]int]bool{}
+	for i, f := range other.File {
+		if f == nil {
+			continue
+		}
+		for _, fileType := range f.Type {
+			j := c.indexFile(fileType)
+			if j < 0 {
+				change(ChangeAdded, "file type", fileType, pointer("file", i))
+				continue
+			}
+			if compared[[2]int{j, i}] {
+				continue
+			}
+			compared[[2]int{j, i}] = true
+			changes = append(changes, diffFile(c.File[j], f, fileType, pointer("file", j), pointer("file", i))...)
+		}
+	}
+	for _, name := range varNames(c.Var, other.Var) {
+		before, inBefore := c.Var[name]
+		after, inAfter := other.Var[name]
+		switch {
+		case !inAfter:
+			change(ChangeRemoved, "var", name, pointer("var", name))
+		case !inBefore:
+			change(ChangeAdded, "var", name, pointer("var", name))
+		case before != after:
+			change(ChangeChanged, "var", name, pointer("var", name))
+		}
+	}
+	for _, change := range changes {
+		if len(change.Impact) == 0 {
+			change.Impact = impact(change)
+		}
+	}
+	return changes
+}
+
+// diffFile returns the changes of the plugins of a File handling the type, and a change of the file type when any other option differs
+func diffFile(before *File, after *File, fileType string, beforePointer string, afterPointer string) []*Change {
+	var changes []*Change
+	var beforePlugins, afterPlugins []*Plugin
+	if before.Modify != nil {
+		beforePlugins = before.Modify.Plugin
+	}
+	if after.Modify != nil {
+		afterPlugins = after.Modify.Plugin
+	}
+	for i, plugin := range afterPlugins {
+		p := afterPointer + pointer("modify", "plugin", i)
+		switch {
+		case i >= len(beforePlugins):
+			changes = append(changes, &Change{Action: ChangeAdded, Element: "plugin", Name: pluginPath(plugin), Pointer: p})
+		case pluginPath(beforePlugins[i]) != pluginPath(plugin):
+			changes = append(changes, &Change{Action: ChangeChanged, Element: "plugin path", Name: pluginPath(plugin), Pointer: p + pointer("path")})
+		case !reflect.DeepEqual(beforePlugins[i], plugin):
+			changes = append(changes, &Change{Action: ChangeChanged, Element: "plugin", Name: pluginPath(plugin), Pointer: p})
+		}
+	}
+	for i := len(afterPlugins); i < len(beforePlugins); i++ {
+		changes = append(changes, &Change{Action: ChangeRemoved, Element: "plugin", Name: pluginPath(beforePlugins[i]), Pointer: beforePointer + pointer("modify", "plugin", i)})
+	}
+	b, a := *before, *after
+	b.Type, a.Type = nil, nil
+	b.Modify, a.Modify = withoutPlugins(before.Modify), withoutPlugins(after.Modify)
+	if !reflect.DeepEqual(b, a) {
+		changes = append(changes, &Change{Action: ChangeChanged, Element: "file type", Name: fileType, Pointer: afterPointer})
+	}
+	return changes
+}
+
+// pluginPath returns the path of the Plugin, or "" for a null Plugin
+func pluginPath(p *Plugin) string {
+	if p == nil {
+		return ""
+	}
+	return p.Path
+}
+
+// withoutPlugins returns a copy of Modify without plugins, or nil
+func withoutPlugins(m *Modify) *Modify {
+	if m == nil {
+		return nil
+	}
+	modify := *m
+	modify.Plugin = nil
+	return &modify
+}
+
+// varNames returns the names of the vars of both maps, sorted
+func varNames(before map[string]string, after map[string]string) []string {
+	names := []string{}
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
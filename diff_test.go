@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Diff(t *testing.T) {
+	before := &configuration.Configuration{
+		Version: "1.0.0",
+		Task:    []*configuration.Task{{Name: "docs"}, {Name: "lint"}},
+		File: []*configuration.File{
+			{Type: []string{"go"}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "a.js"}, {Path: "b.js"}}}},
+			{Type: []string{"md"}},
+		},
+		Var: map[string]string{"src": "src"},
+	}
+	after := &configuration.Configuration{
+		Version: "1.1.0",
+		Task:    []*configuration.Task{{Name: "docs", Notes: "ipsum"}, {Name: "test"}},
+		Script:  []*configuration.Script{{Name: "all", Task: []string{"docs"}}},
+		File: []*configuration.File{
+			{Type: []string{"go", "ts"}, Output: "{{path}}.md", Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "c.js"}}}},
+		},
+		Var: map[string]string{"src": "lib"},
+	}
+	expected := []string{
+		"changed field version /version",
+		"removed task lint /task/1",
+		"changed task docs /task/0",
+		"added task test /task/1",
+		"added script all /script/0",
+		"removed file type md /file/1",
+		"changed plugin path c.js /file/0/modify/plugin/0/path",
+		"removed plugin b.js /file/0/modify/plugin/1",
+		"changed file type go /file/0",
+		"added file type ts /file/0",
+		"changed var src /var/src",
+	}
+	changes := before.Diff(after)
+	if len(changes) != len(expected) {
+		t.Fatalf("Expecting %v changes, got %v", len(expected), changes)
+	}
+	for i, change := range changes {
+		if actual := change.String() + " " + change.Pointer; actual != expected[i] {
+			t.Errorf("Expecting %v, got %v", expected[i], actual)
+		}
+	}
+	if changes := before.Diff(before); len(changes) != 0 {
+		t.Errorf("Expecting no changes, got %v", changes)
+	}
+}
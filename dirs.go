@@ -0,0 +1,64 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Dirs contains the per-user directories: Config for user-wide settings of callers, Cache for copies of remote resources and Data for plugin downloads
+type Dirs struct {
+	Config string
+	Cache  string
+	Data   string
+}
+
+// UserDirs holds the directories used for the remote cache when CacheDir is empty and for plugin downloads, read at each use so reassigning it moves both; defaults to NewDirs
+var UserDirs = NewDirs()
+
+// NewDirs returns the `emits` directory within the user config, cache and data directories of the OS, which follow XDG on Unix; EMITS_CONFIG_DIR, EMITS_CACHE_DIR and EMITS_DATA_DIR override each directory whole
+func NewDirs() *Dirs {
+	return &Dirs{
+		Config: dirFromEnv("EMITS_CONFIG_DIR", os.UserConfigDir),
+		Cache:  dirFromEnv("EMITS_CACHE_DIR", os.UserCacheDir),
+		Data:   dirFromEnv("EMITS_DATA_DIR", userDataDir),
+	}
+}
+
+// PluginDir returns the directory FetchPlugins downloads into when given none
+func (d *Dirs) PluginDir() string {
+	return filepath.Join(d.Data, "plugins")
+}
+
+// dirFromEnv returns the environment variable when set, otherwise `emits` within the user directory, or within the temporary directory when none is known
+func dirFromEnv(variable string, user func() (string, error)) string {
+	if dir := os.Getenv(variable); len(dir) > 0 {
+		return dir
+	}
+	dir, err := user()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "emits")
+}
+
+// userDataDir returns the user data directory: XDG_DATA_HOME or ~/.local/share on Unix, Application Support on macOS and LocalAppData on Windows
+func userDataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); len(dir) > 0 {
+			return dir, nil
+		}
+		return os.UserConfigDir()
+	case "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
@@ -0,0 +1,26 @@
+package configuration_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestNewDirs(t *testing.T) {
+	t.Setenv("EMITS_CONFIG_DIR", "/etc/emits")
+	t.Setenv("EMITS_CACHE_DIR", "/var/cache/emits")
+	t.Setenv("EMITS_DATA_DIR", "")
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dirs := configuration.NewDirs()
+	if dirs.Config != "/etc/emits" || dirs.Cache != "/var/cache/emits" {
+		t.Errorf("Expecting overridden dirs, got %+v", dirs)
+	}
+	if runtime.GOOS == "linux" && dirs.Data != filepath.Join("/data", "emits") {
+		t.Errorf("Expecting XDG data dir, got %v", dirs.Data)
+	}
+	if dirs.PluginDir() != filepath.Join(dirs.Data, "plugins") {
+		t.Errorf("Expecting plugin dir within data, got %v", dirs.PluginDir())
+	}
+}
@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/emits-io/core"
+)
+
+// enabledScript returns the named Script for planning; returns an error if the Script is unknown or disabled
+func (c *Configuration) enabledScript(name string) (*Script, error) {
+	s := c.FindScript(name)
+	if s == nil {
+		return nil, fmt.Errorf("unknown `%s` script definition", name)
+	}
+	if s.Disabled {
+		return nil, fmt.Errorf("`%s` script is disabled", name)
+	}
+	return s, nil
+}
+
+// enabledPlugins returns every Plugin of Modify that is not disabled; none when Modify is nil or disabled
+func (m *Modify) enabledPlugins() []*Plugin {
+	var plugins []*Plugin
+	if m == nil || m.Disabled {
+		return plugins
+	}
+	for _, plugin := range m.Plugin {
+		if plugin == nil {
+			continue
+		}
+		if !plugin.Disabled {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// enabledRegex returns every RegularExpression of Modify; none when Modify is nil or disabled
+func (m *Modify) enabledRegex() []*core.RegularExpression {
+	if m == nil || m.Disabled {
+		return nil
+	}
+	return m.Regex
+}
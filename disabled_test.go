@@ -0,0 +1,102 @@
+package configuration_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_Disabled(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.go": {},
+		"main.js": {},
+	}
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+			},
+			{
+				Name:     "ipsum",
+				Disabled: true,
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"lorem", "ipsum"},
+			},
+			{
+				Name:     "legacy",
+				Task:     []string{"lorem"},
+				Disabled: true,
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{
+							Path: "./foo.js",
+						},
+						{
+							Path:     "./bar.js",
+							Disabled: true,
+						},
+					},
+				},
+			},
+			{
+				Type:     []string{"js"},
+				Disabled: true,
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+	plan, err := c.PlanFS("build", fsys)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(plan.Step) != 1 || len(plan.Step[0].File) != 1 || plan.Step[0].File[0].Path != "main.go" {
+		t.Fatalf("Expecting main.go planned by lorem only, got %v", plan.Step)
+	}
+	if plugins := plan.Step[0].File[0].Plugin; len(plugins) != 1 || plugins[0] != "./foo.js" {
+		t.Errorf("Expecting ./foo.js, got %v", plugins)
+	}
+	c.File[0].Modify.Disabled = true
+	plan, _ = c.PlanFS("build", fsys)
+	if len(plan.Step[0].File[0].Plugin) != 0 {
+		t.Errorf("Expecting no plugins, got %v", plan.Step[0].File[0].Plugin)
+	}
+	if _, err = c.PlanFS("legacy", fsys); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if c.FindFile("js") != nil {
+		t.Errorf("Expecting nil, got %v", c.FindFile("js"))
+	}
+	if affinity := c.TasksByFileType(); len(affinity["go"]) != 1 || affinity["js"] != nil {
+		t.Errorf("Expecting go handled by lorem only, got %v", affinity)
+	}
+	c.Task[1].Path.Include = []string{""}
+	if len(c.Validate()) == 0 {
+		t.Errorf("Expecting disabled task validated, got no errors")
+	}
+}
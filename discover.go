@@ -0,0 +1,54 @@
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ErrConflictingFiles is the error class of a directory holding several configuration files of different content
+var ErrConflictingFiles = errors.New("conflicting configuration files")
+
+// configFileIn returns the path of the file Load selects within dir, ConfigFileFS; returns an error wrapping ErrConflictingFiles when another configuration file present in dir differs from it
+func configFileIn(dir string) (string, error) {
+	files := configFiles(os.DirFS(dir))
+	if len(files) == 0 {
+		return filepath.Join(dir, ConfigFile), nil
+	}
+	selected, err := ioutil.ReadFile(filepath.Join(dir, files[0]))
+	if err != nil {
+		return "", err
+	}
+	for _, name := range files[1:] {
+		other, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(selected, other) {
+			return "", fmt.Errorf("`%s` and `%s` in `%s` are %w; keep only one", files[0], name, dir, ErrConflictingFiles)
+		}
+	}
+	return filepath.Join(dir, files[0]), nil
+}
+
+// Discover returns the path of the file Load would select in startDir, or in the nearest parent directory holding one, like git finds `.git`; returns an error wrapping fs.ErrNotExist when no directory up to the root holds one, or ErrConflictingFiles when the nearest holds several
+func Discover(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if len(configFiles(os.DirFS(dir))) > 0 {
+			return configFileIn(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("`%s` not found in `%s` or any parent directory: %w", ConfigFile, startDir, fs.ErrNotExist)
+		}
+		dir = parent
+	}
+}
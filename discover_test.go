@@ -0,0 +1,67 @@
+package configuration_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "project", "cmd", "emits")
+	os.MkdirAll(nested, 0755)
+	if _, err := configuration.Discover(nested); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expecting fs.ErrNotExist, got %v", err)
+	}
+	os.WriteFile(filepath.Join(root, "emits.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(root, "project", "emits.yaml"), []byte(`name: project`), 0644)
+	os.Mkdir(filepath.Join(nested, "emits.json"), 0755)
+	path, err := configuration.Discover(nested)
+	if err != nil || path != filepath.Join(root, "project", "emits.yaml") {
+		t.Errorf("Expecting project emits.yaml, got %v %v", path, err)
+	}
+	if path, err := configuration.Discover(root); err != nil || path != filepath.Join(root, "emits.json") {
+		t.Errorf("Expecting root emits.json, got %v %v", path, err)
+	}
+}
+
+func TestConfigFileFS_Alternate(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".emitsrc"), []byte(`{"name": "lorem"}`), 0644)
+	if name := configuration.ConfigFileFS(os.DirFS(dir)); name != ".emitsrc" {
+		t.Errorf("Expecting .emitsrc, got %v", name)
+	}
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(dir); err != nil || c.Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v %v", err, c.Name)
+	}
+	c.Version = "1.0.0"
+	if err := c.WriteToPath(dir); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, configuration.ConfigFile)); err == nil {
+		t.Errorf("Expecting .emitsrc written, got %v", configuration.ConfigFile)
+	}
+	if err := c.LoadFrom(dir); err != nil || c.Version != "1.0.0" {
+		t.Errorf("Expecting written version, got %v %v", err, c.Version)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".emitsrc"))
+	os.WriteFile(filepath.Join(dir, "emits.config.json"), data, 0644)
+	if name := configuration.ConfigFileFS(os.DirFS(dir)); name != "emits.config.json" {
+		t.Errorf("Expecting emits.config.json, got %v", name)
+	}
+	if err := (&configuration.Configuration{}).LoadFrom(dir); err != nil {
+		t.Errorf("Expecting identical files to load, got %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, ".emitsrc.json"), []byte(`{"name": "ipsum"}`), 0644)
+	if err := (&configuration.Configuration{}).LoadFrom(dir); !errors.Is(err, configuration.ErrConflictingFiles) {
+		t.Errorf("Expecting ErrConflictingFiles, got %v", err)
+	}
+	if _, err := configuration.Discover(dir); !errors.Is(err, configuration.ErrConflictingFiles) {
+		t.Errorf("Expecting ErrConflictingFiles, got %v", err)
+	}
+}
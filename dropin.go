@@ -0,0 +1,33 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// ConfigDir constant for the directory next to ConfigFile whose JSON fragments are merged into Configuration
+	ConfigDir = "emits.d"
+)
+
+// mergeDropIns merges every `*.json` fragment of the ConfigDir within dir into Configuration in lexical order; fragments replace tasks, scripts and files of the same name like Merge
+func (c *Configuration) mergeDropIns(dir string, options ...Option) error {
+	fragments, err := filepath.Glob(filepath.Join(dir, ConfigDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(fragments)
+	for _, path := range fragments {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		fragment := &Configuration{}
+		if err := fragment.decodeFile(path, options...); err != nil {
+			return fmt.Errorf("%s: %w", filepath.ToSlash(filepath.Join(ConfigDir, filepath.Base(path))), err)
+		}
+		c.Merge(fragment)
+	}
+	return nil
+}
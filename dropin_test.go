@@ -0,0 +1,34 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadDropIns(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"name": "lorem", "task": [{"name": "docs", "path": {"include": ["*.go"]}}]}`), 0644)
+	os.MkdirAll(filepath.Join(dir, configuration.ConfigDir), 0755)
+	os.WriteFile(filepath.Join(dir, configuration.ConfigDir, "20-docs.json"), []byte(`{"task": [{"name": "docs", "path": {"include": ["src/*.go"]}}]}`), 0644)
+	os.WriteFile(filepath.Join(dir, configuration.ConfigDir, "10-lint.json"), []byte(`{"task": [{"name": "lint", "path": {"include": ["*.js"]}}, {"name": "docs", "path": {"include": ["lib/*.go"]}}]}`), 0644)
+	os.WriteFile(filepath.Join(dir, configuration.ConfigDir, "readme.md"), []byte(`ignored`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(dir); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(c.Task) != 2 || c.Task[1].Name != "lint" {
+		t.Fatalf("Expecting docs and lint, got %v", c.Task)
+	}
+	if include := c.FindTask("docs").Path.Include[0]; include != "src/*.go" {
+		t.Errorf("Expecting src/*.go merged last, got %v", include)
+	}
+	os.WriteFile(filepath.Join(dir, configuration.ConfigDir, "30-broken.json"), []byte(`{`), 0644)
+	err := (&configuration.Configuration{}).LoadFrom(dir)
+	if err == nil || !strings.HasPrefix(err.Error(), "emits.d/30-broken.json") {
+		t.Errorf("Expecting emits.d/30-broken.json error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadEnvironment loads the configuration file Load would select, then applies the overlay file of the named environment next to it, such as `emits.dev.json`, with Overlay
+func (c *Configuration) LoadEnvironment(name string, options ...Option) error {
+	return c.LoadEnvironmentFrom(".", name, options...)
+}
+
+// LoadEnvironmentFrom loads the configuration file within the directory, then applies the overlay file of the named environment next to it with Overlay; returns an error if the overlay file does not exist
+func (c *Configuration) LoadEnvironmentFrom(dir string, name string, options ...Option) error {
+	path, err := configFileIn(dir)
+	if err != nil {
+		return err
+	}
+	if err := c.load(path, options...); err != nil {
+		return err
+	}
+	extension := filepath.Ext(path)
+	overlayPath := strings.TrimSuffix(path, extension) + "." + name + extension
+	if _, err := os.Stat(overlayPath); err != nil {
+		return fmt.Errorf("unknown `%s` environment: %w", name, err)
+	}
+	overlay := &Configuration{}
+	if err := overlay.decodeFile(overlayPath, options...); err != nil {
+		return err
+	}
+	c.Overlay(overlay)
+	return c.prepare(options...)
+}
+
+// Overlay applies the layer over Configuration like Merge, except a File of the same name or types is updated rather than replaced: set parse, output and notes replace, modify steps and audits append
+func (c *Configuration) Overlay(layer *Configuration) {
+	if c == nil || layer == nil {
+		return
+	}
+	rest := *layer
+	rest.File = nil
+	c.Merge(&rest)
+	for _, file := range layer.File {
+		if file == nil {
+			continue
+		}
+		overlaid := false
+		for i, f := range c.File {
+			if f == nil {
+				continue
+			}
+			if fileKey(f) == fileKey(file) {
+				c.File[i] = overlayFile(f, file)
+				overlaid = true
+				break
+			}
+		}
+		if !overlaid {
+			c.File = append(c.File, file)
+		}
+	}
+}
+
+// overlayFile returns a copy of the File updated with every option set on the layer
+func overlayFile(f *File, layer *File) *File {
+	file := *f
+	if layer.Parse != nil {
+		file.Parse = layer.Parse
+	}
+	mergeString(&file.Extends, layer.Extends)
+	mergeString(&file.Output, layer.Output)
+	mergeString(&file.Notes, layer.Notes)
+	if layer.Disabled {
+		file.Disabled = true
+	}
+	if layer.Modify != nil {
+		modify := &Modify{}
+		if f.Modify != nil {
+			*modify = *f.Modify
+		}
+		modify.Plugin = append(modify.Plugin[:len(modify.Plugin):len(modify.Plugin)], layer.Modify.Plugin...)
+		modify.Regex = append(modify.Regex[:len(modify.Regex):len(modify.Regex)], layer.Modify.Regex...)
+		if layer.Modify.Disabled {
+			modify.Disabled = true
+		}
+		file.Modify = modify
+	}
+	file.Audit = append(f.Audit[:len(f.Audit):len(f.Audit)], layer.Audit...)
+	return &file
+}
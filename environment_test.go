@@ -0,0 +1,36 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{
+		"name": "lorem",
+		"task": [{"name": "docs", "path": {"include": ["*.go"]}}],
+		"file": [{"type": ["go"], "parse": {"comment": {"line": "//"}}, "modify": {"regex": [{"find": "a", "replace": "b"}]}}]
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "emits.dev.json"), []byte(`{
+		"name": "lorem-dev",
+		"task": [{"name": "debug", "path": {"include": ["debug/*.go"]}}],
+		"file": [{"type": ["go"], "modify": {"regex": [{"find": "DEBUG", "replace": "true"}]}}]
+	}`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadEnvironmentFrom(dir, "dev"); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem-dev" || len(c.Task) != 2 {
+		t.Errorf("Expecting lorem-dev with 2 tasks, got %v %v", c.Name, c.Task)
+	}
+	if len(c.File) != 1 || c.File[0].Parse == nil || len(c.File[0].Modify.Regex) != 2 || c.File[0].Modify.Regex[1].Find != "DEBUG" {
+		t.Errorf("Expecting parse kept and regex appended, got %v", c.File[0])
+	}
+	if err := (&configuration.Configuration{}).LoadEnvironmentFrom(dir, "prod"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
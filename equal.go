@@ -0,0 +1,122 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Equal returns true if other describes the same configuration: nil and empty values are equal, and the order of tasks, scripts, files, params, patterns, file types and requirements is ignored; the order of script tasks, includes, plugins, regex steps and audits is significant
+func (c *Configuration) Equal(other *Configuration) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	a, err := json.Marshal(c.Clone().canonical())
+	if err != nil {
+		return false
+	}
+	b, err := json.Marshal(other.Clone().canonical())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
+
+// canonical sorts every list of Configuration whose order is not significant and returns Configuration
+func (c *Configuration) canonical() *Configuration {
+	sortTasks(c.Task)
+	sort.SliceStable(c.Script, func(i, j int) bool {
+		if c.Script[i] == nil || c.Script[j] == nil {
+			return c.Script[j] != nil
+		}
+		return c.Script[i].Name < c.Script[j].Name
+	})
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		sortParams(s.Param)
+	}
+	sortFiles(c.File)
+	if c.Definitions != nil {
+		sortTasks(c.Definitions.Task)
+		sortFiles(c.Definitions.File)
+	}
+	if c.Requires != nil {
+		sort.Strings(c.Requires.Env)
+		sort.Strings(c.Requires.Commands)
+		sort.Strings(c.Requires.Files)
+	}
+	for _, profile := range c.Profile {
+		if profile != nil {
+			(&Configuration{Task: profile.Task, Script: profile.Script, File: profile.File}).canonical()
+		}
+	}
+	for _, project := range c.Projects {
+		if project == nil {
+			continue
+		}
+		project.canonical()
+	}
+	return c
+}
+
+// sortTasks sorts the tasks by name, and their params and patterns
+func sortTasks(tasks []*Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i] == nil || tasks[j] == nil {
+			return tasks[j] != nil
+		}
+		return tasks[i].Name < tasks[j].Name
+	})
+	for _, t := range tasks {
+		if t == nil {
+			continue
+		}
+		sortParams(t.Param)
+		sort.Strings(t.Inputs)
+		sort.Strings(t.Outputs)
+		if t.Path != nil {
+			if !hasNegation(t.Path.Include) {
+				sort.Strings(t.Path.Include)
+			}
+			sort.Strings(t.Path.Exclude)
+		}
+	}
+}
+
+// sortFiles sorts the types and patterns of every File, then the files by name or types
+func sortFiles(files []*File) {
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		sort.Strings(f.Type)
+		if f.Parse != nil {
+			sort.Strings(f.Parse.Exclude)
+		}
+		for _, a := range f.Audit {
+			if a == nil {
+				continue
+			}
+			sort.Strings(a.Include)
+			sort.Strings(a.Exclude)
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i] == nil || files[j] == nil {
+			return files[j] != nil
+		}
+		return fileKey(files[i]) < fileKey(files[j])
+	})
+}
+
+// sortParams sorts the params by name
+func sortParams(params []*Param) {
+	sort.SliceStable(params, func(i, j int) bool {
+		if params[i] == nil || params[j] == nil {
+			return params[j] != nil
+		}
+		return params[i].Name < params[j].Name
+	})
+}
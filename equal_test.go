@@ -0,0 +1,52 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Equal(t *testing.T) {
+	a := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{Name: "docs", Path: &configuration.Path{Include: []string{"*.go", "*.md"}}},
+			{Name: "lint", Param: []*configuration.Param{}},
+		},
+		Script: []*configuration.Script{{Name: "all", Task: []string{"docs", "lint"}}},
+		File: []*configuration.File{
+			{Type: []string{"go", "ts"}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "a.js"}, {Path: "b.js"}}}},
+			{Type: []string{"md"}},
+		},
+	}
+	b := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{Name: "lint"},
+			{Name: "docs", Path: &configuration.Path{Include: []string{"*.md", "*.go"}}},
+		},
+		Script: []*configuration.Script{{Name: "all", Task: []string{"docs", "lint"}}},
+		File: []*configuration.File{
+			{Type: []string{"md"}, Audit: []*configuration.Audit{}},
+			{Type: []string{"ts", "go"}, Modify: &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "a.js"}, {Path: "b.js"}}}},
+		},
+	}
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Errorf("Expecting equal, got not equal")
+	}
+	if a.Task[0].Name != "docs" || a.Task[0].Path.Include[0] != "*.go" {
+		t.Errorf("Expecting Configuration unchanged, got %+v", a.Task[0])
+	}
+	b.Script[0].Task = []string{"lint", "docs"}
+	if a.Equal(b) {
+		t.Errorf("Expecting script task order significant, got equal")
+	}
+	b.Script[0].Task = []string{"docs", "lint"}
+	b.File[1].Modify.Plugin[0], b.File[1].Modify.Plugin[1] = b.File[1].Modify.Plugin[1], b.File[1].Modify.Plugin[0]
+	if a.Equal(b) {
+		t.Errorf("Expecting plugin order significant, got equal")
+	}
+	if a.Equal(nil) || !(*configuration.Configuration)(nil).Equal(nil) {
+		t.Errorf("Expecting only nil equal to nil")
+	}
+}
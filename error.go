@@ -0,0 +1,184 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Code identifies the kind of a ValidationError for machines, independent of its message
+type Code string
+
+const (
+	// CodeMissing constant for a required definition that is absent
+	CodeMissing Code = "missing"
+	// CodeEmpty constant for a definition that is present but empty
+	CodeEmpty Code = "empty"
+	// CodeInvalid constant for a definition that cannot be parsed or compiled
+	CodeInvalid Code = "invalid"
+	// CodeDuplicate constant for a definition repeated where it must be unique
+	CodeDuplicate Code = "duplicate"
+	// CodeUnknown constant for a reference to a task, param, group or field that does not exist
+	CodeUnknown Code = "unknown"
+	// CodeConflict constant for definitions that contradict each other
+	CodeConflict Code = "conflict"
+	// CodeExtends constant for an Extends reference that cannot be resolved
+	CodeExtends Code = "extends"
+	// CodeRequired constant for a required param that is not provided
+	CodeRequired Code = "required"
+	// CodeEnv constant for an environment variable reference that cannot be interpolated
+	CodeEnv Code = "env"
+	// CodeUnsupported constant for a schema version this package cannot read
+	CodeUnsupported Code = "unsupported"
+	// CodeUnavailable constant for a Requires prerequisite missing from the environment
+	CodeUnavailable Code = "unavailable"
+	// CodeShadowed constant for a pattern already matched by an earlier pattern
+	CodeShadowed Code = "shadowed"
+	// CodeUnreachable constant for a task that can never process a file
+	CodeUnreachable Code = "unreachable"
+	// CodeOverlap constant for tasks of a script that may process the same files
+	CodeOverlap Code = "overlap"
+	// CodeCasing constant for a value that only differs in casing from a known value
+	CodeCasing Code = "casing"
+	// CodeDeprecated constant for a deprecated task or script, or a reference to one
+	CodeDeprecated Code = "deprecated"
+	// CodeType constant for a value whose type differs from the JSON Schema
+	CodeType Code = "type"
+	// CodePolicy constant for a definition violating a Policy
+	CodePolicy Code = "policy"
+	// CodeCycle constant for tasks whose inputs and outputs depend on each other
+	CodeCycle Code = "cycle"
+	// CodeIncomplete constant for filesystem checks skipped once their deadline or budget ran out
+	CodeIncomplete Code = "incomplete"
+)
+
+// Severity identifies whether a ValidationError prevents processing
+type Severity string
+
+const (
+	// SeverityError constant for a finding that prevents processing
+	SeverityError Severity = "error"
+	// SeverityWarning constant for a finding that never prevents processing but usually indicates a mistake
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationError contains a single validation finding, its Code, Severity and the JSON pointer (RFC 6901) of the element it concerns; the message is formatted from Format and Args only when rendered
+type ValidationError struct {
+	Code       Code
+	Severity   Severity
+	Pointer    string
+	Format     string
+	Args       []interface{}
+	Suggestion []string
+	Fix        []*Fix
+}
+
+// Error returns the human readable message of ValidationError
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf(e.Format, e.Args...)
+}
+
+// MarshalJSON returns ValidationError encoded with its rendered message, for editors and CI
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code       Code     `json:"code"`
+		Severity   Severity `json:"severity"`
+		Pointer    string   `json:"pointer"`
+		Message    string   `json:"message"`
+		Suggestion []string `json:"suggestion,omitempty"`
+		Fix        []*Fix   `json:"fix,omitempty"`
+	}{e.Code, e.Severity, e.Pointer, e.Error(), e.Suggestion, e.Fix})
+}
+
+// newError returns an error ValidationError of the Code at the JSON pointer
+func newError(code Code, pointer string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Code:     code,
+		Severity: SeverityError,
+		Pointer:  pointer,
+		Format:   format,
+		Args:     args,
+	}
+}
+
+// newWarning returns a warning ValidationError of the Code at the JSON pointer
+func newWarning(code Code, pointer string, format string, args ...interface{}) *ValidationError {
+	e := newError(code, pointer, format, args...)
+	e.Severity = SeverityWarning
+	return e
+}
+
+// wrapError returns the error as a ValidationError of the Code at the JSON pointer, keeping the code and pointer of an existing ValidationError
+func wrapError(code Code, pointer string, err error) error {
+	if _, ok := err.(*ValidationError); ok {
+		return err
+	}
+	return &ValidationError{
+		Code:     code,
+		Severity: SeverityError,
+		Pointer:  pointer,
+		Format:   "%v",
+		Args:     []interface{}{err},
+	}
+}
+
+// prefix returns the errors with the JSON pointer prepended to every ValidationError; element validators report pointers relative to the element
+func prefix(pointer string, errors []error) []error {
+	for _, err := range errors {
+		if e, ok := err.(*ValidationError); ok {
+			e.Pointer = pointer + e.Pointer
+			for _, fix := range e.Fix {
+				fix.Path = pointer + fix.Path
+			}
+		}
+	}
+	return errors
+}
+
+// ErrorCode returns the Code of a ValidationError, or "" for any other error
+func ErrorCode(err error) Code {
+	if e, ok := err.(*ValidationError); ok {
+		return e.Code
+	}
+	return ""
+}
+
+// ErrorSeverity returns the Severity of a ValidationError, or SeverityError for any other error
+func ErrorSeverity(err error) Severity {
+	if e, ok := err.(*ValidationError); ok && len(e.Severity) > 0 {
+		return e.Severity
+	}
+	return SeverityError
+}
+
+// Pointer returns the JSON pointer of a ValidationError, or the document root for any other error
+func Pointer(err error) string {
+	if e, ok := err.(*ValidationError); ok {
+		return e.Pointer
+	}
+	return ""
+}
+
+// escape replaces `~` and `/` within a JSON pointer reference token
+var escape = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointer returns a JSON pointer built from the reference tokens, escaping `~` and `/`
+func pointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		case string:
+			if strings.ContainsAny(t, "~/") {
+				t = escape.Replace(t)
+			}
+			b.WriteString(t)
+		default:
+			b.WriteString(escape.Replace(fmt.Sprint(t)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestPointer(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+			},
+			{
+				Name: "ipsum",
+				Path: &configuration.Path{
+					Include: []string{"*", " "},
+				},
+			},
+		},
+	}
+	err := c.Validate()
+	if len(err) != 2 {
+		t.Fatalf("Expecting 2 errors, got %v", err)
+	}
+	if configuration.Pointer(err[0]) != "/file" {
+		t.Errorf("Expecting /file, got %v", configuration.Pointer(err[0]))
+	}
+	if configuration.Pointer(err[1]) != "/task/1/path/include/1" {
+		t.Errorf("Expecting /task/1/path/include/1, got %v", configuration.Pointer(err[1]))
+	}
+	if configuration.Pointer(errors.New("plain")) != "" {
+		t.Errorf("Expecting document root, got %v", configuration.Pointer(errors.New("plain")))
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	e := &configuration.ValidationError{
+		Pointer: "/task/0/name",
+		Format:  "`%s` task missing name definition",
+		Args:    []interface{}{"lorem"},
+	}
+	if e.Error() != "`lorem` task missing name definition" {
+		t.Errorf("Expecting formatted message, got %v", e.Error())
+	}
+}
+
+func BenchmarkConfiguration_Validate(b *testing.B) {
+	c := &configuration.Configuration{}
+	for i := 0; i < 200; i++ {
+		c.Task = append(c.Task, &configuration.Task{
+			Name: "lorem",
+			Path: &configuration.Path{
+				Include: []string{"*", " ", " "},
+				Exclude: []string{" "},
+			},
+		})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Validate()
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	c := paramConfiguration()
+	c.Script[0].Task = append(c.Script[0].Task, "bsae")
+	errs := c.Validate()
+	var found bool
+	for _, err := range errs {
+		if configuration.Pointer(err) == "/script/0/task/2" {
+			found = true
+			if configuration.ErrorCode(err) != configuration.CodeUnknown {
+				t.Errorf("Expecting %v, got %v", configuration.CodeUnknown, configuration.ErrorCode(err))
+			}
+			data, _ := json.Marshal(err)
+			if !strings.HasPrefix(string(data), `{"code":"unknown","severity":"error","pointer":"/script/0/task/2","message":"`) {
+				t.Errorf("Expecting encoded code, pointer and message, got %s", data)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expecting error at /script/0/task/2, got %v", errs)
+	}
+	if configuration.ErrorCode(errors.New("plain")) != "" {
+		t.Errorf("Expecting empty code, got %v", configuration.ErrorCode(errors.New("plain")))
+	}
+}
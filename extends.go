@@ -0,0 +1,150 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flatten returns a copy of every Task with Extends resolved; returns an error on unknown or cyclic Extends
+func (c *Configuration) Flatten() ([]*Task, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	var tasks []*Task
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		task, err := c.flatten(t, nil)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// FlattenTask returns a copy of the named Task with Extends resolved, or nil if not found
+func (c *Configuration) FlattenTask(name string) (*Task, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	t := c.FindTask(name)
+	if t == nil {
+		return nil, nil
+	}
+	return c.flatten(t, nil)
+}
+
+// ValidateTaskExtends returns all unknown and cyclic Extends references
+func (c *Configuration) ValidateTaskExtends() []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	var errors []error
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		if len(t.Extends) == 0 {
+			continue
+		}
+		if _, err := c.flatten(t, nil); err != nil {
+			errors = append(errors, wrapError(CodeExtends, pointer("task", i, "extends"), err))
+		}
+	}
+	return errors
+}
+
+// resolve returns the flattened Task when Extends resolves, otherwise the Task as defined; used by Validate
+func (c *Configuration) resolve(t *Task) *Task {
+	task, err := c.flatten(t, nil)
+	if err != nil {
+		return t
+	}
+	return task
+}
+
+// flatten returns a copy of Task merged over every Task it extends; chain tracks visited names for cycle detection
+func (c *Configuration) flatten(t *Task, chain []string) (*Task, error) {
+	if len(t.Extends) == 0 {
+		return t.inherit(nil), nil
+	}
+	chain = append(chain, t.Name)
+	if contains(chain, t.Extends) {
+		return nil, fmt.Errorf("`%s` task extends cycle `%s`", chain[0], strings.Join(append(chain, t.Extends), " > "))
+	}
+	parent := c.FindTask(t.Extends)
+	if parent == nil {
+		parent = c.Definitions.FindTask(t.Extends)
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("`%s` task extends unknown `%s` task definition", t.Name, t.Extends)
+	}
+	base, err := c.flatten(parent, chain)
+	if err != nil {
+		return nil, err
+	}
+	return t.inherit(base), nil
+}
+
+// inherit returns a copy of Task with unset options taken from the parent Task
+func (t *Task) inherit(parent *Task) *Task {
+	task := &Task{
+		Name:       t.Name,
+		Param:      copyParams(t.Param),
+		Inputs:     copyStrings(t.Inputs),
+		Outputs:    copyStrings(t.Outputs),
+		Notes:      t.Notes,
+		Deprecated: t.Deprecated,
+		Disabled:   t.Disabled,
+	}
+	task.Path = t.Path.Clone()
+	if parent == nil {
+		task.Extends = t.Extends
+		return task
+	}
+	if task.Path == nil {
+		task.Path = parent.Path.Clone()
+	} else if parent.Path != nil {
+		if task.Path.Include == nil {
+			task.Path.Include = copyStrings(parent.Path.Include)
+		}
+		if task.Path.Exclude == nil {
+			task.Path.Exclude = copyStrings(parent.Path.Exclude)
+		}
+		if len(task.Path.Symlinks) == 0 {
+			task.Path.Symlinks = parent.Path.Symlinks
+		}
+		if task.Path.MaxDepth == 0 {
+			task.Path.MaxDepth = parent.Path.MaxDepth
+		}
+		if task.Path.Hidden == nil {
+			task.Path.Hidden = copyBool(parent.Path.Hidden)
+		}
+		task.Path.Gitignore = task.Path.Gitignore || parent.Path.Gitignore
+	}
+	if task.Inputs == nil {
+		task.Inputs = copyStrings(parent.Inputs)
+	}
+	if task.Outputs == nil {
+		task.Outputs = copyStrings(parent.Outputs)
+	}
+	for _, param := range parent.Param {
+		if param == nil {
+			continue
+		}
+		if task.FindParam(param.Name) == nil {
+			task.Param = append(task.Param, copyParams([]*Param{param})...)
+		}
+	}
+	return task
+}
+
+// copyStrings returns a copy of the slice, preserving nil
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
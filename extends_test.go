@@ -0,0 +1,90 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Flatten(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "base",
+				Path: &configuration.Path{
+					Include:   []string{"src/*"},
+					Exclude:   []string{"src/vendor/*"},
+					Gitignore: true,
+				},
+			},
+			{
+				Name:    "docs",
+				Extends: "base",
+				Path: &configuration.Path{
+					Include: []string{"docs/*"},
+				},
+			},
+			{
+				Name:    "copy",
+				Extends: "docs",
+			},
+		},
+	}
+	tasks, err := c.Flatten()
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("Expecting 3 tasks, got %v", len(tasks))
+	}
+	if tasks[1].Path.Include[0] != "docs/*" || tasks[1].Path.Exclude[0] != "src/vendor/*" || !tasks[1].Path.Gitignore {
+		t.Errorf("Expecting docs include and inherited exclude and gitignore, got %v", tasks[1].Path)
+	}
+	if tasks[2].Path.Include[0] != "docs/*" || tasks[2].Extends != "" {
+		t.Errorf("Expecting resolved copy of docs, got %v", tasks[2])
+	}
+	if c.Task[2].Path != nil {
+		t.Errorf("Expecting Flatten to leave tasks unmodified, got %v", c.Task[2].Path)
+	}
+	for _, task := range c.Task {
+		errTask := task.Validate()
+		if task.Extends == "" && errTask != nil {
+			t.Errorf("Expecting nil, got %v", errTask)
+		}
+	}
+	errValidate := c.Validate()
+	if len(errValidate) != 1 {
+		t.Errorf("Expecting only the missing file definition error, got %v", errValidate)
+	}
+}
+
+func TestConfiguration_ValidateTaskExtends(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name:    "lorem",
+				Extends: "ipsum",
+			},
+			{
+				Name:    "ipsum",
+				Extends: "lorem",
+			},
+			{
+				Name:    "dolor",
+				Extends: "unknown",
+			},
+		},
+	}
+	err := c.ValidateTaskExtends()
+	if len(err) != 3 {
+		t.Errorf("Expecting 3 errors, got %v", err)
+	}
+	_, errFlatten := c.Flatten()
+	if errFlatten == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	task, errFlatten := c.FlattenTask("foo")
+	if task != nil || errFlatten != nil {
+		t.Errorf("Expecting nil, got %v %v", task, errFlatten)
+	}
+}
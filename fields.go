@@ -0,0 +1,78 @@
+package configuration
+
+import (
+	"reflect"
+	"strings"
+)
+
+// jsonName returns the JSON key of the struct field, or "" if the field is never encoded
+func jsonName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "-" {
+		return ""
+	}
+	if len(tag) == 0 {
+		return field.Name
+	}
+	return tag
+}
+
+// jsonFields returns every encoded field of the struct type keyed by JSON key, in declaration order
+func jsonFields(t reflect.Type) ([]string, map[string]reflect.StructField) {
+	var names []string
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if len(name) == 0 {
+			continue
+		}
+		names = append(names, name)
+		fields[name] = t.Field(i)
+	}
+	return names, fields
+}
+
+// indirect returns the type with every pointer removed
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// typeAt returns the Go type of the element addressed by the JSON pointer within Configuration, or nil if the pointer does not exist in the schema
+func typeAt(p string) reflect.Type {
+	t := reflect.TypeOf(Configuration{})
+	for _, token := range tokens(p) {
+		t = indirect(t)
+		switch t.Kind() {
+		case reflect.Struct:
+			_, fields := jsonFields(t)
+			field, ok := fields[token]
+			if !ok {
+				return nil
+			}
+			t = field.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return nil
+		}
+	}
+	return indirect(t)
+}
+
+// tokens returns the unescaped reference tokens of the JSON pointer
+func tokens(p string) []string {
+	if len(p) == 0 {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, part := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+	}
+	return parts
+}
@@ -0,0 +1,74 @@
+package configuration
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// Files returns the sorted slash separated paths under root, relative to root, matching Include and no Exclude pattern of Task; a `!pattern` Include removes the paths matched by the patterns before it, and a later pattern includes them again; a Task without Path matches no file
+func (t *Task) Files(root string) ([]string, error) {
+	return t.FilesFS(os.DirFS(root))
+}
+
+// FilesContext is Files bounded by ctx: the directory scan stops with its error once ctx is done
+func (t *Task) FilesContext(ctx context.Context, root string) ([]string, error) {
+	return t.FilesFSContext(ctx, os.DirFS(root))
+}
+
+// FilesFS returns the sorted paths of the file system matching Include and no Exclude pattern of Task, as Files does
+func (t *Task) FilesFS(fsys fs.FS) ([]string, error) {
+	return t.FilesFSContext(context.Background(), fsys)
+}
+
+// FilesFSContext is FilesFS bounded by ctx: the directory scan stops with its error once ctx is done
+func (t *Task) FilesFSContext(ctx context.Context, fsys fs.FS) ([]string, error) {
+	if t == nil || t.Path == nil {
+		return nil, nil
+	}
+	return resolveFilesFS(ctx, fsys, t.Path)
+}
+
+// FindFile returns the enabled File, with Extends resolved, handling the provided type if found or nil if not found
+func (c *Configuration) FindFile(fileType string) *File {
+	if c == nil {
+		return nil
+	}
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file := c.resolveFile(f)
+		if !file.Disabled && contains(file.Type, fileType) {
+			return file
+		}
+	}
+	return nil
+}
+
+// resolveFiles returns the sorted slash separated paths under root the Path matches
+func resolveFiles(root string, p *Path) ([]string, error) {
+	return resolveFilesFS(context.Background(), os.DirFS(root), p)
+}
+
+// resolveFilesFS returns the sorted paths of the file system, walked until ctx is done, matching the include patterns of the Path, where a later `!` negation removes what earlier patterns matched, and no exclude pattern; a Path with Gitignore skips ignored files and symbolic links are treated as its Symlinks directs
+func resolveFilesFS(ctx context.Context, fsys fs.FS, p *Path) ([]string, error) {
+	var files []string
+	w := newWalker(ctx, fsys, p)
+	err := w.walk(func(relative string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		matched, err := w.visit(relative, d)
+		if matched {
+			files = append(files, relative)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
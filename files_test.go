@@ -0,0 +1,58 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestTask_Files(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"main.go", "main_test.go", "lib/util.go", "vendor/x/x.go", "README.md"} {
+		os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(root, name), nil, 0644)
+	}
+	task := &configuration.Task{
+		Name: "go",
+		Path: &configuration.Path{
+			Include: []string{"**/*.go"},
+			Exclude: []string{"vendor/**", "**/*_test.go"},
+		},
+	}
+	files, err := task.Files(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if expecting := []string{"lib/util.go", "main.go"}; !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v", expecting, files)
+	}
+	if files, err := (&configuration.Task{Name: "empty"}).Files(root); files != nil || err != nil {
+		t.Errorf("Expecting no files, got %v %v", files, err)
+	}
+	if _, err := task.Files(filepath.Join(root, "missing")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	task.Path = &configuration.Path{
+		Include: []string{"lib/**/*.{go,md}", "*.md"},
+	}
+	if files, err := task.Files(root); err != nil || !reflect.DeepEqual(files, []string{"README.md", "lib/util.go"}) {
+		t.Errorf("Expecting [README.md lib/util.go], got %v %v", files, err)
+	}
+	task.Path.Include = []string{"**/*.go", "!**/*_test.go", "!vendor/**", "vendor/x/*.go", `\!*`}
+	if files, err := task.Files(root); err != nil || !reflect.DeepEqual(files, []string{"lib/util.go", "main.go", "vendor/x/x.go"}) {
+		t.Errorf("Expecting [lib/util.go main.go vendor/x/x.go], got %v %v", files, err)
+	}
+	task.Path.Include = []string{"!**/*_test.go"}
+	if files, err := task.Files(root); err != nil || files != nil {
+		t.Errorf("Expecting no files, got %v %v", files, err)
+	}
+	task.Path.Include = []string{"**/*.go"}
+	fsys := fstest.MapFS{"a.go": {}, "b.txt": {}}
+	if files, err := task.FilesFS(fsys); err != nil || !reflect.DeepEqual(files, []string{"a.go"}) {
+		t.Errorf("Expecting [a.go], got %v %v", files, err)
+	}
+}
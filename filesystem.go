@@ -0,0 +1,132 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path"
+)
+
+// errBudget stops a filesystem walk once the stat budget is spent
+var errBudget = errors.New("stat budget exceeded")
+
+// fsChecker counts the files stat'ed or walked against the budget and the context deadline
+type fsChecker struct {
+	ctx    context.Context
+	fsys   fs.FS
+	budget int
+	spent  int
+}
+
+// spend counts one file and returns an error once the budget is spent or the context is done
+func (f *fsChecker) spend() error {
+	if err := f.ctx.Err(); err != nil {
+		return err
+	}
+	if f.budget > 0 && f.spent >= f.budget {
+		return errBudget
+	}
+	f.spent++
+	return nil
+}
+
+// exists returns true if the slash separated path exists within the file system
+func (f *fsChecker) exists(name string) (bool, error) {
+	if err := f.spend(); err != nil {
+		return false, err
+	}
+	_, err := fs.Stat(f.fsys, path.Clean(name))
+	return err == nil, nil
+}
+
+// matches returns true if any file of the file system matches the Path
+func (f *fsChecker) matches(p *Path) (bool, error) {
+	found := false
+	w := newWalker(f.ctx, f.fsys, p)
+	err := w.walk(func(relative string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if err := f.spend(); err != nil {
+			return err
+		}
+		matched, err := w.visit(relative, d)
+		if matched {
+			found = true
+			return fs.SkipAll
+		}
+		return err
+	})
+	return found, err
+}
+
+// ValidateFilesystem returns an error for every enabled plugin or audit script missing from the file system and a warning for every enabled task matching no file; once the context is done or WithStatBudget is spent the remaining checks are skipped with a single warning
+func (c *Configuration) ValidateFilesystem(ctx context.Context, fsys fs.FS, options ...Option) []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	checker := &fsChecker{
+		ctx:    ctx,
+		fsys:   fsys,
+		budget: newOptions(options).budget,
+	}
+	var findings []error
+	stopped := func(err error) []error {
+		return append(findings, newWarning(CodeIncomplete, "", "filesystem checks stopped after %d files: %v; remaining checks skipped", checker.spent, err))
+	}
+	for i, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file := c.resolveFile(f)
+		if file.Disabled {
+			continue
+		}
+		if file.Modify != nil && !file.Modify.Disabled {
+			for j, plugin := range file.Modify.Plugin {
+				if plugin == nil {
+					continue
+				}
+				if plugin.Disabled {
+					continue
+				}
+				found, err := checker.exists(plugin.Path)
+				if err != nil {
+					return stopped(err)
+				}
+				if !found {
+					findings = append(findings, newError(CodeMissing, pointer("file", i, "modify", "plugin", j, "path"), "plugin `%s` does not exist", plugin.Path))
+				}
+			}
+		}
+		for j, audit := range file.Audit {
+			if audit == nil {
+				continue
+			}
+			found, err := checker.exists(audit.Path)
+			if err != nil {
+				return stopped(err)
+			}
+			if !found {
+				findings = append(findings, newError(CodeMissing, pointer("file", i, "audit", j, "path"), "audit `%s` does not exist", audit.Path))
+			}
+		}
+	}
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		task := c.resolve(t)
+		if task.Disabled || task.Path == nil || len(task.Path.Include) == 0 {
+			continue
+		}
+		found, err := checker.matches(task.Path)
+		if err != nil {
+			return stopped(err)
+		}
+		if !found {
+			findings = append(findings, newWarning(CodeEmpty, pointer("task", i, "path", "include"), "`%s` task path include definitions match no file", task.Name))
+		}
+	}
+	return findings
+}
@@ -0,0 +1,48 @@
+package configuration_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_ValidateFilesystem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"plugins/a.js": {},
+		"src/main.go":  {},
+		"src/util.go":  {},
+	}
+	c := validConfiguration()
+	c.Task = append(c.Task, &configuration.Task{Name: "docs", Path: &configuration.Path{Include: []string{"docs/*.md"}}})
+	c.Task[0].Path.Include = []string{"src/*.go"}
+	c.File[0].Modify = &configuration.Modify{Plugin: []*configuration.Plugin{{Path: "plugins/a.js"}, {Path: "plugins/b.js"}}}
+	findings := c.ValidateFilesystem(context.Background(), fsys)
+	expected := []string{
+		"plugin `plugins/b.js` does not exist",
+		"`docs` task path include definitions match no file",
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Expecting %v findings, got %v", len(expected), findings)
+	}
+	for i, finding := range findings {
+		if finding.Error() != expected[i] {
+			t.Errorf("Expecting %v, got %v", expected[i], finding)
+		}
+	}
+	if configuration.ErrorSeverity(findings[1]) != configuration.SeverityWarning {
+		t.Errorf("Expecting warning, got %v", configuration.ErrorSeverity(findings[1]))
+	}
+	findings = c.ValidateFilesystem(context.Background(), fsys, configuration.WithStatBudget(3))
+	last := findings[len(findings)-1].(*configuration.ValidationError)
+	if last.Code != configuration.CodeIncomplete || last.Severity != configuration.SeverityWarning || last.Error() != "filesystem checks stopped after 3 files: stat budget exceeded; remaining checks skipped" {
+		t.Errorf("Expecting incomplete warning, got %v", last)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	findings = c.ValidateFilesystem(ctx, fsys)
+	if len(findings) != 1 || findings[0].(*configuration.ValidationError).Code != configuration.CodeIncomplete {
+		t.Errorf("Expecting a single incomplete warning, got %v", findings)
+	}
+}
@@ -0,0 +1,47 @@
+package configuration
+
+// Fix contains a single edit resolving a finding, in the shape of a JSON Patch (RFC 6902) operation
+type Fix struct {
+	Title string      `json:"title"`
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// replace returns the ValidationError with a Fix replacing the value at the JSON pointer
+func (e *ValidationError) replace(title string, path string, value interface{}) *ValidationError {
+	e.Fix = append(e.Fix, &Fix{
+		Title: title,
+		Op:    "replace",
+		Path:  path,
+		Value: value,
+	})
+	return e
+}
+
+// add returns the ValidationError with a Fix adding the value at the JSON pointer
+func (e *ValidationError) add(title string, path string, value interface{}) *ValidationError {
+	e.Fix = append(e.Fix, &Fix{
+		Title: title,
+		Op:    "add",
+		Path:  path,
+		Value: value,
+	})
+	return e
+}
+
+// Fixes returns every Fix attached to the Report errors and warnings
+func (r *Report) Fixes() []*Fix {
+	if r == nil {
+		return nil
+	}
+	var fixes []*Fix
+	for _, findings := range [][]error{r.Errors, r.Warnings} {
+		for _, finding := range findings {
+			if e, ok := finding.(*ValidationError); ok {
+				fixes = append(fixes, e.Fix...)
+			}
+		}
+	}
+	return fixes
+}
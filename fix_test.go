@@ -0,0 +1,47 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestReport_Fixes(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"lorme", "unrelated"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"GO", "custom"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+	fixes := c.Report().Fixes()
+	if len(fixes) != 2 {
+		t.Fatalf("Expecting 2 fixes, got %v", fixes)
+	}
+	if fixes[0].Op != "replace" || fixes[0].Path != "/script/0/task/0" || fixes[0].Value != "lorem" {
+		t.Errorf("Expecting lorem replacement at /script/0/task/0, got %v", fixes[0])
+	}
+	if fixes[1].Path != "/file/0/type/0" || fixes[1].Value != "go" {
+		t.Errorf("Expecting go replacement at /file/0/type/0, got %v", fixes[1])
+	}
+}
@@ -0,0 +1,36 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// lockPath returns the lock file guarding the file at path within the `locks` directory of the cache dir, named by the hash of its absolute path so every writer of the file shares it
+func lockPath(cache string, path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(cache, "locks", hex.EncodeToString(sum[:])+".lock")
+}
+
+// acquireLock blocks until the exclusive advisory lock of the file at path, kept within the cache dir, is held and returns the function releasing it; writers replace files atomically, so readers take no lock
+func acquireLock(cache string, path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Join(cache, "locks"), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(lockPath(cache, path), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, true); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,79 @@
+package configuration_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_WriteToPath_Concurrent(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	if err := (&configuration.Configuration{Name: "lorem"}).WriteToPath(path); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			c := &configuration.Configuration{Name: fmt.Sprintf("lorem-%v", i), Description: "ipsum dolor sit amet"}
+			errs <- c.WriteToPath(path)
+		}(i)
+		go func() {
+			defer wg.Done()
+			c := &configuration.Configuration{}
+			errs <- c.LoadFrom(path)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expecting nil, got %v", err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expecting only the file, got %v entries", len(entries))
+	}
+	if entries, _ := os.ReadDir(filepath.Join(configuration.CacheDir, "locks")); len(entries) != 1 {
+		t.Errorf("Expecting 1 lock file within the cache dir, got %v", len(entries))
+	}
+}
+
+func TestConfiguration_LoadFrom_NoLock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	os.WriteFile(path, []byte(`{"name": "lorem"}`), 0644)
+	if err := (&configuration.Configuration{}).LoadFrom(path, configuration.WithCacheDir(dir)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expecting no lock file left by a read, got %v entries", len(entries))
+	}
+}
+
+func TestConfiguration_WriteToPath_LockError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	cache := filepath.Join(dir, "cache")
+	if err := os.WriteFile(cache, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := (&configuration.Configuration{Name: "lorem"}).WriteToPath(path, configuration.WithCacheDir(cache)); err == nil {
+		t.Errorf("Expecting error when the lock file cannot be created, got nil")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expecting no file written unlocked, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build unix
+
+package configuration
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile places a shared or exclusive flock on the file, blocking until it is granted
+func lockFile(f *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases the flock on the file
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,316 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the encoding of a configuration document
+type Format string
+
+const (
+	// JSON constant for the JSON configuration format
+	JSON Format = "json"
+	// YAML constant for the YAML configuration format
+	YAML Format = "yaml"
+	// TOML constant for the TOML configuration format
+	TOML Format = "toml"
+)
+
+// Convert returns the document re-encoded from one Format to another; unknown fields are kept and field ordering is preserved where the target Format allows; any registered Codec may be used
+func Convert(in []byte, from, to Format) ([]byte, error) {
+	node, err := decodeNode(in, from)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s: %v", from, err)
+	}
+	out, err := encodeNode(node, to)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode %s: %v", to, err)
+	}
+	return out, nil
+}
+
+// decodeNode returns the document as an ordered yaml.Node tree regardless of Format
+func decodeNode(in []byte, format Format) (*yaml.Node, error) {
+	switch format {
+	case JSON:
+		decoder := json.NewDecoder(bytes.NewReader(in))
+		decoder.UseNumber()
+		node, err := jsonNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("unexpected data after top-level value")
+		}
+		return node, nil
+	case YAML:
+		var document yaml.Node
+		if err := yaml.Unmarshal(in, &document); err != nil {
+			return nil, err
+		}
+		if len(document.Content) == 0 {
+			return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+		}
+		return document.Content[0], nil
+	case TOML:
+		var value map[string]interface{}
+		meta, err := toml.Decode(string(in), &value)
+		if err != nil {
+			return nil, err
+		}
+		order := map[string]int{}
+		for i, key := range meta.Keys() {
+			if _, ok := order[key.String()]; !ok {
+				order[key.String()] = i
+			}
+		}
+		return valueNode(value, "", order), nil
+	}
+	if codec := FindCodec(format); codec != nil {
+		var value interface{}
+		if err := codec.Unmarshal(in, &value); err != nil {
+			return nil, err
+		}
+		return valueNode(value, "", nil), nil
+	}
+	return nil, fmt.Errorf("unsupported format `%s`", format)
+}
+
+// encodeNode returns the ordered yaml.Node tree encoded as Format
+func encodeNode(node *yaml.Node, format Format) ([]byte, error) {
+	switch format {
+	case JSON:
+		var compact bytes.Buffer
+		if err := writeJSON(&compact, node); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, compact.Bytes(), "", "\t"); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	case YAML:
+		var out bytes.Buffer
+		encoder := yaml.NewEncoder(&out)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(node); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	case TOML:
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("top-level value must be a table")
+		}
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		encoder := toml.NewEncoder(&out)
+		encoder.Indent = ""
+		if err := encoder.Encode(dropNull(value)); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+	if codec := FindCodec(format); codec != nil {
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		return codec.Marshal(value)
+	}
+	return nil, fmt.Errorf("unsupported format `%s`", format)
+}
+
+// jsonNode reads the next JSON value from the decoder as a yaml.Node, keeping object key order
+func jsonNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch value := token.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if value == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for decoder.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := jsonNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(value.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%v", value)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
+
+// writeJSON writes the yaml.Node tree as compact JSON, keeping mapping key order
+func writeJSON(out *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			out.WriteString("null")
+			return nil
+		}
+		return writeJSON(out, node.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(out, node.Alias)
+	case yaml.MappingNode:
+		out.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := writeValue(out, node.Content[i].Value); err != nil {
+				return err
+			}
+			out.WriteByte(':')
+			if err := writeJSON(out, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		out.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := writeJSON(out, child); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+		return nil
+	}
+	if (node.ShortTag() == "!!int" || node.ShortTag() == "!!float") && json.Valid([]byte(node.Value)) {
+		out.WriteString(node.Value)
+		return nil
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	if t, ok := value.(time.Time); ok {
+		value = t.Format(time.RFC3339Nano)
+	}
+	return writeValue(out, value)
+}
+
+// writeValue writes a scalar as JSON without escaping HTML characters, which are common in comment syntax
+func writeValue(out *bytes.Buffer, value interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	out.Truncate(out.Len() - 1)
+	return nil
+}
+
+// valueNode returns a decoded TOML value as a yaml.Node; table keys are ordered by their first appearance in the document
+func valueNode(value interface{}, path string, order map[string]int) *yaml.Node {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		position := func(key string) int {
+			if i, ok := order[strings.TrimPrefix(path+"."+key, ".")]; ok {
+				return i
+			}
+			return len(order)
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			if position(keys[i]) != position(keys[j]) {
+				return position(keys[i]) < position(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		for _, key := range keys {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, valueNode(v[key], strings.TrimPrefix(path+"."+key, "."), order))
+		}
+		return node
+	case []map[string]interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, child := range v {
+			node.Content = append(node.Content, valueNode(child, path, order))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, child := range v {
+			node.Content = append(node.Content, valueNode(child, path, order))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%v", v)}
+	case int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: fmt.Sprintf("%v", v)}
+	case float64:
+		data, _ := json.Marshal(v)
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: string(data)}
+	case time.Time:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.Format(time.RFC3339Nano)}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprintf("%v", value)}
+}
+
+// dropNull returns the decoded value without null entries, which TOML cannot represent
+func dropNull(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if child == nil {
+				delete(v, key)
+			} else {
+				v[key] = dropNull(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = dropNull(child)
+		}
+	}
+	return value
+}
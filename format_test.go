@@ -0,0 +1,91 @@
+package configuration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+const document = `{
+	"name": "Name",
+	"version": "1.0.0",
+	"task": [
+		{
+			"name": "lorem",
+			"path": {
+				"include": ["*"],
+				"exclude": ["vendor/*"]
+			}
+		}
+	],
+	"file": [
+		{
+			"type": ["go"],
+			"parse": {
+				"comment": {
+					"line": "//",
+					"block": {
+						"start": "/*",
+						"end": "*/"
+					}
+				},
+				"source": true
+			},
+			"modify": {
+				"regex": [
+					{
+						"find": "(?P<year>\\d{4})",
+						"replace": "${year}"
+					}
+				]
+			}
+		}
+	],
+	"unknown": "kept"
+}`
+
+func TestConvert(t *testing.T) {
+	yamlData, err := configuration.Convert([]byte(document), configuration.JSON, configuration.YAML)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.HasPrefix(string(yamlData), "name: Name\nversion: 1.0.0\ntask:\n") {
+		t.Errorf("Expecting ordered yaml, got %s", yamlData)
+	}
+	jsonData, err := configuration.Convert(yamlData, configuration.YAML, configuration.JSON)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	var expected, actual bytes.Buffer
+	json.Compact(&expected, []byte(document))
+	json.Compact(&actual, jsonData)
+	if expected.String() != actual.String() {
+		t.Errorf("Expecting %s, got %s", expected.String(), actual.String())
+	}
+	tomlData, err := configuration.Convert(yamlData, configuration.YAML, configuration.TOML)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	jsonData, err = configuration.Convert(tomlData, configuration.TOML, configuration.JSON)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	var original, converted interface{}
+	json.Unmarshal([]byte(document), &original)
+	json.Unmarshal(jsonData, &converted)
+	if !reflect.DeepEqual(original, converted) {
+		t.Errorf("Expecting %v, got %v", original, converted)
+	}
+	_, err = configuration.Convert([]byte(document), configuration.JSON, "xml")
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	_, err = configuration.Convert([]byte(`{"name":`), configuration.JSON, configuration.YAML)
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
@@ -0,0 +1,113 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// gitPrefix constant for the scheme prefix of a file within a git repository, such as `git+https://example.com/repo.git#main/emits.json`
+	gitPrefix = "git+"
+)
+
+// GitCommand is the git executable used to fetch `git+` locations
+var GitCommand = "git"
+
+// gitProtocols contains the configuration of every git invocation restricting the transports a repository may be fetched with to https, ssh and file
+var gitProtocols = []string{"-c", "protocol.allow=never", "-c", "protocol.https.allow=always", "-c", "protocol.ssh.allow=always", "-c", "protocol.file.allow=always"}
+
+// isGit returns true if the location is a `git+` location
+func isGit(location string) bool {
+	return strings.HasPrefix(location, gitPrefix)
+}
+
+// splitGit returns the repository url, ref and slash separated file path of a `git+<repository>#<ref>/<path>` location; the ref is the first segment of the fragment; a repository or ref git could read as an option is refused
+func splitGit(location string) (string, string, string, error) {
+	repository, fragment, ok := strings.Cut(strings.TrimPrefix(location, gitPrefix), "#")
+	if !ok {
+		return "", "", "", fmt.Errorf("`%s` git location missing `#<ref>/<path>`", location)
+	}
+	ref, file, ok := strings.Cut(fragment, "/")
+	if !ok || len(ref) == 0 || len(file) == 0 {
+		return "", "", "", fmt.Errorf("`%s` git location missing ref or path", location)
+	}
+	if len(repository) == 0 || strings.HasPrefix(repository, "-") || strings.HasPrefix(ref, "-") || strings.Contains(ref, ":") {
+		return "", "", "", fmt.Errorf("`%s` git location has an invalid repository or ref", location)
+	}
+	return repository, ref, file, nil
+}
+
+// gitJoin returns the location of name relative to the directory of the file at the git location, within the same repository and ref
+func gitJoin(location string, name string) (string, error) {
+	repository, ref, file, err := splitGit(location)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s#%s/%s", gitPrefix, repository, ref, path.Join(path.Dir(file), name)), nil
+}
+
+// fetchGit returns the content of the file at the git location, kept in the cache dir of the options and used for RemoteTTL unless forced; while Offline only the cached copy is used
+func fetchGit(o *options, location string, force bool) ([]byte, error) {
+	cache := o.cacheDir()
+	if Offline {
+		return cached(cache, location)
+	}
+	if info, err := os.Stat(cachePath(cache, location)); err == nil && !force && time.Since(info.ModTime()) < RemoteTTL {
+		return ioutil.ReadFile(cachePath(cache, location))
+	}
+	ctx := o.context()
+	repository, ref, file, err := splitGit(location)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(repository))
+	dir := filepath.Join(cache, "git", hex.EncodeToString(sum[:]))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	unlock, err := acquireLock(cache, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil {
+		if _, err := git(ctx, dir, "init", "--quiet", "--bare"); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := git(ctx, dir, "fetch", "--quiet", "--depth", "1", "--", repository, ref); err != nil {
+		return nil, err
+	}
+	data, err := git(ctx, dir, "show", "FETCH_HEAD:"+file)
+	if err != nil {
+		return nil, err
+	}
+	store(cache, location, data)
+	return data, nil
+}
+
+// git runs GitCommand with the arguments in dir, restricted to gitProtocols, and returns its output; the error includes what git reported
+func git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, GitCommand, append(append([]string{}, gitProtocols...), args...)...)
+	command.Dir = dir
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package configuration_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func gitRepository(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"checkout", "--quiet", "-b", "main"},
+		{"add", "."},
+		{"-c", "user.name=emits", "-c", "user.email=emits@example.com", "commit", "--quiet", "-m", "initial"},
+	} {
+		command := exec.Command("git", args...)
+		command.Dir = dir
+		if output, err := command.CombinedOutput(); err != nil {
+			t.Fatalf("Expecting nil, got %v %s", err, output)
+		}
+	}
+	return dir
+}
+
+func TestConfiguration_LoadFrom_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	repository := gitRepository(t, map[string]string{
+		"configs/emits.json": `{"name": "shared", "extends": "base.json"}`,
+		"configs/base.json":  `{"task": [{"name": "docs", "path": {"include": ["*.go"]}}]}`,
+	})
+	location := "git+file://" + filepath.ToSlash(repository) + "#main/configs/emits.json"
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(location); err != nil || c.Name != "shared" || c.FindTask("docs") == nil {
+		t.Fatalf("Expecting shared with docs task, got %v %v", err, c)
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"name": "local", "extends": "`+location+`"}`), 0644)
+	c = &configuration.Configuration{}
+	if err := c.LoadFrom(dir); err != nil || c.Name != "local" || c.FindTask("docs") == nil {
+		t.Errorf("Expecting local extending docs task, got %v %v", err, c)
+	}
+	if err := (&configuration.Configuration{}).LoadFrom("git+file://" + filepath.ToSlash(repository) + "#main/missing.json"); err == nil {
+		t.Errorf("Expecting missing file error, got nil")
+	}
+	if err := (&configuration.Configuration{}).LoadFrom("git+file://" + filepath.ToSlash(repository)); err == nil {
+		t.Errorf("Expecting missing ref error, got nil")
+	}
+}
+
+func TestConfiguration_LoadFrom_GitInjection(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	pwned := filepath.Join(t.TempDir(), "pwned")
+	for _, location := range []string{
+		"git+--upload-pack=touch " + filepath.ToSlash(pwned) + ";false#main/emits.json",
+		"git+file:///tmp/repository#--upload-pack=touch " + filepath.ToSlash(pwned) + "/emits.json",
+		"git+ext::sh -c touch% " + filepath.ToSlash(pwned) + "#main/emits.json",
+	} {
+		if err := (&configuration.Configuration{}).LoadFrom(location); err == nil {
+			t.Errorf("Expecting error for %s, got nil", location)
+		}
+		if _, err := os.Stat(pwned); err == nil {
+			t.Fatalf("Expecting no command run for %s, got %s", location, pwned)
+		}
+	}
+}
@@ -1,5 +1,12 @@
 module github.com/emits-io/configuration
 
-go 1.17
+go 1.20
 
-require github.com/emits-io/core v1.0.5
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/emits-io/core v1.0.5
+	github.com/fsnotify/fsnotify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.4.0 // indirect
@@ -0,0 +1,87 @@
+package configuration
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+const (
+	// IgnoreFile constant for the optional file listing gitignore patterns of files no task processes, honoured alongside .gitignore by a Path with Gitignore
+	IgnoreFile = ".emitsignore"
+)
+
+// ignoreFiles lists the files read in every directory of a Path with Gitignore, in order of increasing precedence
+var ignoreFiles = []string{".gitignore", IgnoreFile}
+
+// ignoreRule contains a single gitignore pattern rewritten relative to the root of the file system
+type ignoreRule struct {
+	glob   glob
+	negate bool
+	dir    bool
+}
+
+// escapeGlob escapes every character match treats specially but gitignore reads literally
+var escapeGlob = strings.NewReplacer("{", "\\{", "}", "\\}")
+
+// escapeDir escapes every glob meta character of a directory name
+var escapeDir = strings.NewReplacer("*", "\\*", "?", "\\?", "[", "\\[", "\\", "\\\\", "{", "\\{", "}", "\\}")
+
+// parseIgnore returns the rules of the gitignore data found in the slash separated dir
+func parseIgnore(dir string, data []byte) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \r")
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dir = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		anchored := strings.Contains(line, "/")
+		line = escapeGlob.Replace(strings.TrimPrefix(line, "/"))
+		if len(line) == 0 {
+			continue
+		}
+		if !anchored {
+			line = "**/" + line
+		}
+		if dir != "." {
+			line = escapeDir.Replace(dir) + "/" + line
+		}
+		rule.glob = compileGlob(line)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// loadIgnore returns the rules of every ignore file present in the slash separated dir of the file system
+func loadIgnore(fsys fs.FS, dir string) []ignoreRule {
+	var rules []ignoreRule
+	for _, name := range ignoreFiles {
+		if data, err := fs.ReadFile(fsys, path.Join(dir, name)); err == nil {
+			rules = append(rules, parseIgnore(dir, data)...)
+		}
+	}
+	return rules
+}
+
+// ignored returns true if the last rule matching the slash separated name ignores it
+func ignored(rules []ignoreRule, name string, dir bool) bool {
+	ignore := false
+	for _, rule := range rules {
+		if rule.dir && !dir {
+			continue
+		}
+		if rule.glob.match(name) {
+			ignore = !rule.negate
+		}
+	}
+	return ignore
+}
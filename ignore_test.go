@@ -0,0 +1,51 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestPath_Gitignore(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":             "# generated\n/build/\n*.log\n!keep.log\ngen/\n",
+		configuration.IgnoreFile: "docs/draft.md\n",
+		"main.go":                "",
+		"app.log":                "",
+		"keep.log":               "",
+		"build/out.go":           "",
+		"lib/build/in.go":        "",
+		"lib/gen/x.go":           "",
+		"lib/.gitignore":         "*.md\n",
+		"lib/a.md":               "",
+		"docs/draft.md":          "",
+		"docs/guide.md":          "",
+		".git/config.go":         "",
+	}
+	for name, data := range files {
+		os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(root, name), []byte(data), 0644)
+	}
+	task := &configuration.Task{
+		Name: "all",
+		Path: &configuration.Path{
+			Include:   []string{"**/*.{go,log,md}"},
+			Gitignore: true,
+		},
+	}
+	matched, err := task.Files(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if expecting := []string{"docs/guide.md", "keep.log", "lib/build/in.go", "main.go"}; !reflect.DeepEqual(matched, expecting) {
+		t.Errorf("Expecting %v, got %v", expecting, matched)
+	}
+	task.Path.Gitignore = false
+	if matched, err := task.Files(root); err != nil || len(matched) != 10 {
+		t.Errorf("Expecting 10 files, got %v %v", matched, err)
+	}
+}
@@ -0,0 +1,96 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// includeFragments merges every fragment file Include names, in order, into Configuration; fragments contribute definitions only and may not redefine a task, script, file, definition or var already defined
+func (c *Configuration) includeFragments(dir string, chain []string, options ...Option) error {
+	for _, link := range c.Include {
+		name, sum := splitPin(link)
+		path, absolute, err := linkedPath(dir, name)
+		if err != nil {
+			return err
+		}
+		if contains(chain, absolute) {
+			return fmt.Errorf("include cycle `%s`", strings.Join(append(chain, absolute), " > "))
+		}
+		fragment := &Configuration{}
+		if err := fragment.decodeLinked(path, sum, options...); err != nil {
+			return fmt.Errorf("include `%s`: %w", name, err)
+		}
+		if len(fragment.Extends) > 0 {
+			return fmt.Errorf("include `%s`: fragments cannot extend a base configuration", name)
+		}
+		if err := fragment.includeFragments(linkedDir(path), append(chain, absolute), options...); err != nil {
+			return err
+		}
+		if err := c.mergeFragment(fragment); err != nil {
+			return fmt.Errorf("include `%s`: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// mergeFragment appends the definitions of the fragment to Configuration; returns an error on the first name defined by both
+func (c *Configuration) mergeFragment(fragment *Configuration) error {
+	for _, t := range fragment.Task {
+		if t == nil {
+			continue
+		}
+		if c.FindTask(t.Name) != nil {
+			return fmt.Errorf("`%s` task is already defined", t.Name)
+		}
+	}
+	for _, s := range fragment.Script {
+		if s == nil {
+			continue
+		}
+		if c.FindScript(s.Name) != nil {
+			return fmt.Errorf("`%s` script is already defined", s.Name)
+		}
+	}
+	for _, f := range fragment.File {
+		if f == nil {
+			continue
+		}
+		for _, fileType := range f.Type {
+			if c.indexFile(fileType) >= 0 {
+				return fmt.Errorf("`%s` file type is already defined", fileType)
+			}
+		}
+	}
+	if fragment.Definitions != nil {
+		for _, t := range fragment.Definitions.Task {
+			if t == nil {
+				continue
+			}
+			if c.Definitions.FindTask(t.Name) != nil {
+				return fmt.Errorf("`%s` definition task is already defined", t.Name)
+			}
+		}
+		for _, f := range fragment.Definitions.File {
+			if f == nil {
+				continue
+			}
+			if c.Definitions.FindFile(f.Name) != nil {
+				return fmt.Errorf("`%s` definition file is already defined", f.Name)
+			}
+		}
+	}
+	for name := range fragment.Var {
+		if _, ok := c.Var[name]; ok {
+			return fmt.Errorf("`%s` var is already defined", name)
+		}
+	}
+	c.Merge(&Configuration{
+		Task:        fragment.Task,
+		Script:      fragment.Script,
+		File:        fragment.File,
+		Definitions: fragment.Definitions,
+		Requires:    fragment.Requires,
+		Var:         fragment.Var,
+	})
+	return nil
+}
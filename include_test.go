@@ -0,0 +1,41 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadInclude(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{
+		"name": "lorem",
+		"include": ["emits.tasks.json", "emits.files.json"],
+		"script": [{"name": "build", "task": ["docs"]}]
+	}`), 0644)
+	os.WriteFile(filepath.Join(dir, "emits.tasks.json"), []byte(`{"name": "ignored", "task": [{"name": "docs", "path": {"include": ["*.go"]}}]}`), 0644)
+	os.WriteFile(filepath.Join(dir, "emits.files.json"), []byte(`{"file": [{"type": ["go"], "parse": {"comment": {"line": "//"}}}]}`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(dir); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem" || len(c.Task) != 1 || len(c.File) != 1 || len(c.Include) != 2 {
+		t.Errorf("Expecting fragments merged, got %v %v %v %v", c.Name, c.Task, c.File, c.Include)
+	}
+	if errs := c.Validate(); len(errs) != 0 {
+		t.Errorf("Expecting nil, got %v", errs)
+	}
+	os.WriteFile(filepath.Join(dir, "emits.files.json"), []byte(`{"task": [{"name": "docs", "path": {"include": ["*.js"]}}]}`), 0644)
+	err := (&configuration.Configuration{}).LoadFrom(dir)
+	if err == nil || !strings.Contains(err.Error(), "include `emits.files.json`: `docs` task is already defined") {
+		t.Errorf("Expecting duplicate task, got %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "emits.files.json"), []byte(`{"include": ["emits.json"]}`), 0644)
+	err = (&configuration.Configuration{}).LoadFrom(dir)
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Expecting include cycle, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package configuration
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// InferFiles scans the project at root, counts the file extensions present and returns a File definition with the comment syntax of its preset for every registered preset found, most common first; each File only types the extensions present
+func InferFiles(root string) ([]*File, error) {
+	count := map[string]int{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && contains(skipDirectory, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if extension := strings.TrimPrefix(filepath.Ext(d.Name()), "."); len(extension) > 0 {
+			count[extension]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	total := map[string]int{}
+	found := map[string]*File{}
+	for _, name := range Presets() {
+		preset := Preset(name)
+		if preset == nil {
+			continue
+		}
+		var types []string
+		for _, extension := range preset.Type {
+			if n := count[extension]; n > 0 {
+				types = append(types, extension)
+				total[name] += n
+				delete(count, extension)
+			}
+		}
+		if len(types) > 0 {
+			preset.Type = types
+			found[name] = preset
+		}
+	}
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if total[names[i]] != total[names[j]] {
+			return total[names[i]] > total[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	var files []*File
+	for _, name := range names {
+		files = append(files, found[name])
+	}
+	return files, nil
+}
@@ -0,0 +1,36 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestInferFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"main.go", "util.go", "lib/a.py", "lib/b.py", "lib/c.py", "lib/d.h", "README.md", "node_modules/x.js"} {
+		os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(root, name), nil, 0644)
+	}
+	files, err := configuration.InferFiles(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expecting 3 files, got %v", files)
+	}
+	if files[0].Type[0] != "py" || files[0].Parse.Comment.Line != "#" {
+		t.Errorf("Expecting python first, got %v %v", files[0].Type, files[0].Parse.Comment)
+	}
+	if files[1].Type[0] != "go" || files[1].Parse.Comment.Line != "//" {
+		t.Errorf("Expecting go second, got %v %v", files[1].Type, files[1].Parse.Comment)
+	}
+	if len(files[2].Type) != 1 || files[2].Type[0] != "h" {
+		t.Errorf("Expecting only the present c types, got %v", files[2].Type)
+	}
+	if _, err := configuration.InferFiles(filepath.Join(root, "missing")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
@@ -0,0 +1,63 @@
+package configuration
+
+import (
+	"path/filepath"
+)
+
+// InitOptions contains the settings of a Configuration generated by Init
+type InitOptions struct {
+	// Root is the directory Detect inspects for languages and project kinds; the current directory when empty
+	Root string
+	// Name is the name of the Configuration; the base name of Root when empty
+	Name string
+	// Languages names the presets to define files for, such as `go` or `python` or any registered with RegisterPreset, instead of those Detect finds in Root
+	Languages []string
+}
+
+// Init returns a starter Configuration ready to validate: a `default` task including every file except those of the detected project kinds, a `default` script running it and a File definition with comment syntax for every detected or named language
+func Init(opts InitOptions) *Configuration {
+	root := opts.Root
+	if len(root) == 0 {
+		root = "."
+	}
+	name := opts.Name
+	if len(name) == 0 {
+		if absolute, err := filepath.Abs(root); err == nil {
+			name = filepath.Base(absolute)
+		}
+	}
+	exclude := []string{".git/**"}
+	var files []*File
+	if suggestions, err := Detect(root); err == nil {
+		exclude = suggestions.Exclude
+		files = suggestions.File
+	}
+	if opts.Languages != nil {
+		files = nil
+		for _, name := range opts.Languages {
+			if preset := Preset(name); preset != nil {
+				files = append(files, preset)
+			}
+		}
+	}
+	return &Configuration{
+		SchemaVersion: ConfigSchema,
+		Name:          name,
+		Task: []*Task{
+			{
+				Name: "default",
+				Path: &Path{
+					Include: []string{"**/*"},
+					Exclude: exclude,
+				},
+			},
+		},
+		Script: []*Script{
+			{
+				Name: "default",
+				Task: []string{"default"},
+			},
+		},
+		File: files,
+	}
+}
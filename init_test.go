@@ -0,0 +1,40 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "lorem")
+	os.MkdirAll(root, 0755)
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example\n"), 0644)
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644)
+	os.WriteFile(filepath.Join(root, "run.sh"), []byte(""), 0644)
+	c := configuration.Init(configuration.InitOptions{Root: root})
+	if errs := c.Validate(); errs != nil {
+		t.Errorf("Expecting valid configuration, got %v", errs)
+	}
+	if c.Name != "lorem" || c.SchemaVersion != configuration.ConfigSchema {
+		t.Errorf("Expecting lorem at current schema, got %v %v", c.Name, c.SchemaVersion)
+	}
+	if task := c.FindTask("default"); task == nil || task.Path.Include[0] != "**/*" || task.Path.Exclude[1] != "vendor/**" {
+		t.Errorf("Expecting default task excluding vendor, got %v", task)
+	}
+	if s := c.FindScript("default"); s == nil || s.Task[0] != "default" {
+		t.Errorf("Expecting default script, got %v", s)
+	}
+	if c.FindFile("go") == nil || c.FindFile("sh") == nil {
+		t.Errorf("Expecting go and sh file definitions, got %v", c.File)
+	}
+	c = configuration.Init(configuration.InitOptions{Root: root, Name: "ipsum", Languages: []string{"python"}})
+	if c.Name != "ipsum" || len(c.File) != 1 || c.FindFile("py") == nil {
+		t.Errorf("Expecting ipsum with python file definition, got %v %v", c.Name, c.File)
+	}
+	if errs := c.Validate(); errs != nil {
+		t.Errorf("Expecting valid configuration, got %v", errs)
+	}
+}
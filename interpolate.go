@@ -0,0 +1,87 @@
+package configuration
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envReference matches a `{{env.NAME}}` environment variable reference within a string value
+var envReference = regexp.MustCompile(`\{\{\s*env\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Interpolate replaces every `{{env.NAME}}` reference within string values with the value of the environment variable; WithEnvPrefix restricts the variables that may be referenced
+func (c *Configuration) Interpolate(options ...Option) error {
+	if errs := c.ValidateEnv(options...); errs != nil {
+		return errs[0]
+	}
+	visitStrings(reflect.ValueOf(c), "", func(p string, value reflect.Value) {
+		value.SetString(envReference.ReplaceAllStringFunc(value.String(), func(reference string) string {
+			return os.Getenv(envReference.FindStringSubmatch(reference)[1])
+		}))
+	})
+	return nil
+}
+
+// EnvDependencies returns the name of every environment variable referenced by Configuration, sorted
+func (c *Configuration) EnvDependencies() []string {
+	var names []string
+	visitStrings(reflect.ValueOf(c), "", func(p string, value reflect.Value) {
+		for _, match := range envReference.FindAllStringSubmatch(value.String(), -1) {
+			if !contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+// ValidateEnv returns an error for every environment variable reference outside the WithEnvPrefix namespace or not set
+func (c *Configuration) ValidateEnv(options ...Option) []error {
+	o := newOptions(options)
+	var errors []error
+	visitStrings(reflect.ValueOf(c), "", func(p string, value reflect.Value) {
+		for _, match := range envReference.FindAllStringSubmatch(value.String(), -1) {
+			if !strings.HasPrefix(match[1], o.envPrefix) {
+				errors = append(errors, newError(CodeEnv, p, "environment variable `%s` is outside the `%s` prefix", match[1], o.envPrefix))
+			} else if _, ok := os.LookupEnv(match[1]); !ok {
+				errors = append(errors, newError(CodeEnv, p, "environment variable `%s` is not set", match[1]))
+			}
+		}
+	})
+	return errors
+}
+
+// visitStrings calls visit with the JSON pointer and settable value of every encoded string within value
+func visitStrings(value reflect.Value, p string, visit func(p string, value reflect.Value)) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			visitStrings(value.Elem(), p, visit)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if name := jsonName(value.Type().Field(i)); len(name) > 0 {
+				visitStrings(value.Field(i), p+pointer(name), visit)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			visitStrings(value.Index(i), p+pointer(i), visit)
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, key := range keys {
+			visitStrings(value.MapIndex(key), p+pointer(key.String()), visit)
+		}
+	case reflect.String:
+		if value.CanSet() {
+			visit(p, value)
+		}
+	}
+}
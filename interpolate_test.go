@@ -0,0 +1,46 @@
+package configuration_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Interpolate(t *testing.T) {
+	os.Setenv("EMITS_TEST_DIR", "src")
+	os.Setenv("OTHER_TEST_DIR", "lib")
+	defer os.Unsetenv("EMITS_TEST_DIR")
+	defer os.Unsetenv("OTHER_TEST_DIR")
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"{{env.EMITS_TEST_DIR}}/**", "{{ env.OTHER_TEST_DIR }}/**"},
+				},
+			},
+		},
+	}
+	deps := c.EnvDependencies()
+	if len(deps) != 2 || deps[0] != "EMITS_TEST_DIR" || deps[1] != "OTHER_TEST_DIR" {
+		t.Errorf("Expecting 2 dependencies, got %v", deps)
+	}
+	errs := c.ValidateEnv(configuration.WithEnvPrefix("EMITS_"))
+	if len(errs) != 1 || configuration.Pointer(errs[0]) != "/task/0/path/include/1" {
+		t.Errorf("Expecting 1 error at /task/0/path/include/1, got %v", errs)
+	}
+	if err := c.Interpolate(configuration.WithEnvPrefix("EMITS_")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if err := c.Interpolate(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Task[0].Path.Include[0] != "src/**" || c.Task[0].Path.Include[1] != "lib/**" {
+		t.Errorf("Expecting interpolated includes, got %v", c.Task[0].Path.Include)
+	}
+	c.Name = "{{env.EMITS_TEST_UNSET}}"
+	if errs := c.ValidateEnv(); len(errs) != 1 {
+		t.Errorf("Expecting 1 error, got %v", errs)
+	}
+}
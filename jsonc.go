@@ -0,0 +1,80 @@
+package configuration
+
+import (
+	"encoding/json"
+)
+
+const (
+	// JSONC constant for the JSON with comments and trailing commas configuration format
+	JSONC Format = "jsonc"
+)
+
+func init() {
+	RegisterCodec(jsoncCodec{})
+}
+
+// jsoncCodec is the built-in Codec of JSON with `//` and `/* */` comments and trailing commas
+type jsoncCodec struct{}
+
+// Name returns the JSONC Format
+func (jsoncCodec) Name() string {
+	return string(JSONC)
+}
+
+// Extensions returns the JSONC file extensions
+func (jsoncCodec) Extensions() []string {
+	return []string{"jsonc", "json5"}
+}
+
+// Marshal returns v encoded as tab indented JSON, which is valid JSONC
+func (jsoncCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "\t")
+}
+
+// Unmarshal decodes JSONC data into v
+func (jsoncCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(StripJSONC(data), v)
+}
+
+// StripJSONC returns the document with comments and trailing commas replaced by whitespace; byte offsets and line numbers are unchanged so errors and Positions still point into the original document
+func StripJSONC(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+	comma := -1
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			comma = -1
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out) && !(out[i] == '*' && i+1 < len(out) && out[i+1] == '/'); i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			if i < len(out) {
+				out[i], out[i+1] = ' ', ' '
+				i++
+			}
+		case out[i] == ',':
+			comma = i
+		case out[i] == '}' || out[i] == ']':
+			if comma >= 0 {
+				out[comma] = ' '
+			}
+			comma = -1
+		case out[i] != ' ' && out[i] != '\t' && out[i] != '\r' && out[i] != '\n':
+			comma = -1
+		}
+	}
+	return out
+}
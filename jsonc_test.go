@@ -0,0 +1,51 @@
+package configuration_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+const annotated = `{
+	// project name
+	"name": "lorem // not a comment",
+	/* tasks
+	   processed in order */
+	"task": [
+		{
+			"name": "ipsum",
+			"path": {
+				"include": ["*.go", "*.md",],
+			},
+		},
+	],
+}`
+
+func TestStripJSONC(t *testing.T) {
+	out := configuration.StripJSONC([]byte(annotated))
+	if len(out) != len(annotated) {
+		t.Errorf("Expecting %v bytes, got %v", len(annotated), len(out))
+	}
+	if _, err := configuration.Positions(out); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+}
+
+func TestConfiguration_LoadTolerant(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	os.WriteFile(configuration.ConfigFile, []byte(annotated), 0644)
+	c := &configuration.Configuration{}
+	if err := c.Load(); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	c = &configuration.Configuration{}
+	if err := c.Load(configuration.WithTolerant()); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem // not a comment" || len(c.Task[0].Path.Include) != 2 {
+		t.Errorf("Expecting annotated document, got %v %v", c.Name, c.Task[0].Path.Include)
+	}
+}
@@ -0,0 +1,88 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Jsonnet constant for the Jsonnet configuration format
+	Jsonnet Format = "jsonnet"
+)
+
+func init() {
+	RegisterCodec(&JsonnetCodec{
+		Command:     "jsonnet",
+		LibraryPath: filepath.SplitList(os.Getenv("JSONNET_PATH")),
+	})
+}
+
+// JsonnetCodec evaluates Jsonnet documents with the Jsonnet command line interpreter; imports resolve relative to the directory of the loaded file, then LibraryPath in order
+type JsonnetCodec struct {
+	Command     string
+	LibraryPath []string
+}
+
+// Name returns the Jsonnet Format
+func (j *JsonnetCodec) Name() string {
+	return string(Jsonnet)
+}
+
+// Extensions returns the Jsonnet file extensions
+func (j *JsonnetCodec) Extensions() []string {
+	return []string{"jsonnet"}
+}
+
+// Marshal returns v encoded as tab indented JSON, which is valid Jsonnet
+func (j *JsonnetCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "\t")
+}
+
+// Unmarshal evaluates the Jsonnet data, resolving imports relative to the working directory, and decodes the resulting JSON into v
+func (j *JsonnetCodec) Unmarshal(data []byte, v interface{}) error {
+	evaluated, err := j.Evaluate(data, "")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(evaluated, v)
+}
+
+// Evaluate returns the JSON the Jsonnet data evaluates to; the interpreter runs in dir, when not empty, so relative imports resolve against it, while a relative Command or LibraryPath stays relative to the working directory
+func (j *JsonnetCodec) Evaluate(data []byte, dir string) ([]byte, error) {
+	args := []string{}
+	for _, library := range j.LibraryPath {
+		if len(library) > 0 {
+			args = append(args, "-J", absolutePath(library))
+		}
+	}
+	name := j.Command
+	if strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, '/') {
+		name = absolutePath(name)
+	}
+	command := exec.Command(name, append(args, "-")...)
+	command.Dir = dir
+	command.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) > 0 {
+			return nil, fmt.Errorf("could not evaluate jsonnet: %s", message)
+		}
+		return nil, fmt.Errorf("could not evaluate jsonnet: %v", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// absolutePath returns path made absolute against the working directory, or path unchanged when that fails
+func absolutePath(path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		return absolute
+	}
+	return path
+}
@@ -0,0 +1,84 @@
+package configuration_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestJsonnetCodec_Load(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interpreter stub requires a posix shell")
+	}
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "jsonnet")
+	os.WriteFile(stub, []byte("#!/bin/sh\n[ \"$1\" = \"-J\" ] && [ \"$2\" = \"$PWD/lib\" ] && echo '{\"name\":\"lorem\"}'\n"), 0755)
+	original := configuration.FindCodec(configuration.Jsonnet)
+	defer configuration.RegisterCodec(original)
+	configuration.RegisterCodec(&configuration.JsonnetCodec{
+		Command:     stub,
+		LibraryPath: []string{"lib"},
+	})
+	wd, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(wd)
+	os.WriteFile("emits.jsonnet", []byte("{ name: 'lo' + 'rem' }"), 0644)
+	c := &configuration.Configuration{}
+	if err := c.Load(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v", c.Name)
+	}
+}
+
+func TestJsonnetCodec_LoadFrom(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interpreter stub requires a posix shell")
+	}
+	dir := t.TempDir()
+	stub, count := filepath.Join(dir, "jsonnet"), filepath.Join(dir, "count")
+	os.WriteFile(stub, []byte("#!/bin/sh\necho >> "+count+"\n[ -f local.libsonnet ] && echo '{\"name\":\"lorem\"}'\n"), 0755)
+	original := configuration.FindCodec(configuration.Jsonnet)
+	defer configuration.RegisterCodec(original)
+	configuration.RegisterCodec(&configuration.JsonnetCodec{Command: stub})
+	project := filepath.Join(dir, "project")
+	os.Mkdir(project, 0755)
+	os.WriteFile(filepath.Join(project, "local.libsonnet"), []byte("{ name: 'lorem' }"), 0644)
+	os.WriteFile(filepath.Join(project, "emits.jsonnet"), []byte("(import 'local.libsonnet')"), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(filepath.Join(project, "emits.jsonnet"), configuration.WithStrict(), configuration.WithSchemaValidation()); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v", c.Name)
+	}
+	if data, _ := os.ReadFile(count); len(data) != 1 {
+		t.Errorf("Expecting a single evaluation, got %v", len(data))
+	}
+}
+
+func TestJsonnetCodec_Unmarshal(t *testing.T) {
+	command, err := exec.LookPath("jsonnet")
+	if err != nil {
+		t.Skip("jsonnet interpreter not installed")
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "lib.libsonnet"), []byte("{ task(name):: { name: name, path: { include: ['*'] } } }"), 0644)
+	codec := &configuration.JsonnetCodec{
+		Command:     command,
+		LibraryPath: []string{dir},
+	}
+	c := &configuration.Configuration{}
+	err = codec.Unmarshal([]byte("local lib = import 'lib.libsonnet'; { task: [lib.task(n) for n in ['a', 'b']] }"), c)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(c.Task) != 2 || c.Task[1].Name != "b" {
+		t.Errorf("Expecting 2 tasks, got %v", c.Task)
+	}
+}
@@ -0,0 +1,108 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lint returns all known warnings of Task; warnings never prevent processing but usually indicate a mistake
+func (t *Task) Lint() []error {
+	var warnings []error
+	if t == nil || t.Path == nil {
+		return warnings
+	}
+	// last holds the index of the last include and negation seen; a pattern is only redundant if no pattern of the other kind follows its earlier occurrence
+	last := map[bool]int{false: -1, true: -1}
+	for i, include := range t.Path.Include {
+		negation := strings.HasPrefix(include, "!")
+		for j := 0; j < i; j++ {
+			if t.Path.Include[j] == include && j > last[!negation] {
+				warnings = append(warnings, newWarning(CodeDuplicate, pointer("path", "include", i), "`%s` task path include definition `%s` at index `%v` duplicates index `%v`", t.Name, include, i, j))
+				break
+			}
+			if !negation && j > last[true] && covers(t.Path.Include[j], include) {
+				warnings = append(warnings, newWarning(CodeShadowed, pointer("path", "include", i), "`%s` task path include definition `%s` at index `%v` is shadowed by `%s` at index `%v`", t.Name, include, i, t.Path.Include[j], j))
+				break
+			}
+		}
+		last[negation] = i
+	}
+	for i, exclude := range t.Path.Exclude {
+		for j := 0; j < i; j++ {
+			if t.Path.Exclude[j] == exclude {
+				warnings = append(warnings, newWarning(CodeDuplicate, pointer("path", "exclude", i), "`%s` task path exclude definition `%s` at index `%v` duplicates index `%v`", t.Name, exclude, i, j))
+				break
+			}
+		}
+	}
+	if len(t.Path.Include) > 0 && len(t.Path.Exclude) > 0 {
+		excluded := true
+		for _, include := range positive(t.Path.Include) {
+			if !coveredByAny(t.Path.Exclude, include) {
+				excluded = false
+				break
+			}
+		}
+		if excluded {
+			warnings = append(warnings, newWarning(CodeUnreachable, pointer("path", "exclude"), "`%s` task path exclude definitions cover every include definition; the task will never process a file", t.Name))
+		}
+	}
+	return warnings
+}
+
+// Lint returns all known warnings of File
+func (f *File) Lint() []error {
+	var warnings []error
+	if f == nil {
+		return warnings
+	}
+	for i, fileType := range f.Type {
+		lower := strings.ToLower(fileType)
+		if lower != fileType && findLanguage(lower) != nil {
+			warnings = append(warnings, newWarning(CodeCasing, pointer("type", i), "`%s` file type at index `%v` differs in casing from known `%s` type", fileType, i, lower).replace(fmt.Sprintf("Replace with `%s`", lower), pointer("type", i), lower))
+		}
+	}
+	warnings = append(warnings, f.lintOutput()...)
+	return warnings
+}
+
+// Lint returns all known warnings at once; tasks and files are linted with Extends resolved
+func (c *Configuration) Lint() []error {
+	var warnings []error
+	if c == nil {
+		return warnings
+	}
+	if len(strings.TrimSpace(c.Description)) == 0 {
+		warnings = append(warnings, newWarning(CodeMissing, pointer("description"), "`%s` missing description definition", ConfigFile))
+	}
+	if len(strings.TrimSpace(c.Version)) == 0 {
+		warnings = append(warnings, newWarning(CodeMissing, pointer("version"), "`%s` missing version definition", ConfigFile))
+	}
+	for i, task := range c.Task {
+		if task == nil {
+			continue
+		}
+		warnTask := c.resolve(task).Lint()
+		if warnTask != nil {
+			warnings = append(warnings, prefix(pointer("task", i), warnTask)...)
+		}
+	}
+	for i, file := range c.File {
+		if file == nil {
+			continue
+		}
+		warnFile := c.resolveFile(file).Lint()
+		if warnFile != nil {
+			warnings = append(warnings, prefix(pointer("file", i), warnFile)...)
+		}
+	}
+	warnDeprecated := c.lintDeprecations()
+	if warnDeprecated != nil {
+		warnings = append(warnings, warnDeprecated...)
+	}
+	warnOverlap := c.lintOverlaps()
+	if warnOverlap != nil {
+		warnings = append(warnings, warnOverlap...)
+	}
+	return warnings
+}
@@ -0,0 +1,100 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestTask_Lint(t *testing.T) {
+	task := &configuration.Task{
+		Name: "lorem",
+		Path: &configuration.Path{
+			Include: []string{"src/**/*.go", "docs/*.md", "src/**/*.go", "src/cmd/main.go", "src/**/*_test.go", "docs/**"},
+			Exclude: []string{"vendor/**", "vendor/**"},
+		},
+	}
+	warnings := task.Lint()
+	if len(warnings) != 4 {
+		t.Errorf("Expecting 4 warnings, got %v", warnings)
+	}
+	task.Path.Include = []string{"src/*.go", "src/**/*.go", "*.md"}
+	task.Path.Exclude = nil
+	warnings = task.Lint()
+	if warnings != nil {
+		t.Errorf("Expecting nil, got %v", warnings)
+	}
+	task.Path.Include = []string{"src/**", "!src/gen/**", "src/gen/keep.go", "!src/gen/**", "src/**/*.go"}
+	warnings = task.Lint()
+	if warnings != nil {
+		t.Errorf("Expecting nil, got %v", warnings)
+	}
+	task.Path.Include = []string{"src/**", "!src/gen/**", "!src/gen/**", "src/gen/*.go", "src/gen/keep.go"}
+	warnings = task.Lint()
+	if len(warnings) != 2 {
+		t.Errorf("Expecting 2 warnings, got %v", warnings)
+	}
+}
+
+func TestTask_LintExcluded(t *testing.T) {
+	task := &configuration.Task{
+		Name: "lorem",
+		Path: &configuration.Path{
+			Include: []string{"src/*.go", "src/cmd/**/*.go"},
+			Exclude: []string{"src/**"},
+		},
+	}
+	warnings := task.Lint()
+	if len(warnings) != 1 {
+		t.Errorf("Expecting 1 warning, got %v", warnings)
+	}
+	task.Path.Include = append(task.Path.Include, "docs/*.md")
+	warnings = task.Lint()
+	if warnings != nil {
+		t.Errorf("Expecting nil, got %v", warnings)
+	}
+}
+
+func TestConfiguration_Report(t *testing.T) {
+	c := &configuration.Configuration{
+		Description: "report",
+		Version:     "1.0.0",
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"**/*", "*.go"},
+				},
+			},
+		},
+	}
+	report := c.Report()
+	if len(report.Errors) != 1 || len(report.Warnings) != 1 {
+		t.Errorf("Expecting 1 error and 1 warning, got %v %v", report.Errors, report.Warnings)
+	}
+}
+
+func TestConfiguration_ReportSeverity(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+			},
+		},
+	}
+	report := c.Report()
+	if len(report.Errors) != 1 || len(report.Warnings) != 2 {
+		t.Fatalf("Expecting 1 error and 2 warnings, got %v %v", report.Errors, report.Warnings)
+	}
+	for _, warning := range report.Warnings {
+		if configuration.ErrorSeverity(warning) != configuration.SeverityWarning {
+			t.Errorf("Expecting warning severity, got %v", configuration.ErrorSeverity(warning))
+		}
+	}
+	if configuration.ErrorSeverity(report.Errors[0]) != configuration.SeverityError {
+		t.Errorf("Expecting error severity, got %v", configuration.ErrorSeverity(report.Errors[0]))
+	}
+}
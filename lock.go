@@ -0,0 +1,112 @@
+package configuration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// LockFile constant for the file WriteLock records the resolved inputs of every task to
+	LockFile = "emits.lock.json"
+	// LockSchema constant for the serialized Lock schema version; incremented on any incompatible change
+	LockSchema = 1
+)
+
+// Lock contains the concrete files matched by the path patterns of every enabled task, so downstream runs can be reproduced and skipped when nothing changed
+type Lock struct {
+	Schema int         `json:"schema"`
+	Task   []*LockTask `json:"task"`
+}
+
+// LockTask contains a single Task of Lock with the files its path patterns matched
+type LockTask struct {
+	Name string        `json:"name"`
+	File []*LockedFile `json:"file"`
+}
+
+// LockedFile contains a single matched file of LockTask and the sha256 hash of its content
+type LockedFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// WriteLock records the files matched by every task under the current directory, with their content hashes, to LockFile
+func (c *Configuration) WriteLock() error {
+	return c.WriteLockRoot(".")
+}
+
+// WriteLockRoot records the files matched by every task under root, with their content hashes, to LockFile within root
+func (c *Configuration) WriteLockRoot(root string) error {
+	lock, err := c.LockFS(os.DirFS(root))
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(lock, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeAtomic(filepath.Join(root, LockFile), append(data, '\n'), 0644)
+}
+
+// LockFS returns the Lock of the file system: every enabled task, with Extends resolved and in declaration order, and the sorted files its path patterns match
+func (c *Configuration) LockFS(fsys fs.FS) (*Lock, error) {
+	return c.LockFSContext(context.Background(), fsys)
+}
+
+// LockFSContext is LockFS bounded by ctx: the directory scan stops with its error once ctx is done
+func (c *Configuration) LockFSContext(ctx context.Context, fsys fs.FS) (*Lock, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	lock := &Lock{
+		Schema: LockSchema,
+		Task:   []*LockTask{},
+	}
+	for _, t := range c.enabledTasks() {
+		task := &LockTask{
+			Name: t.Name,
+			File: []*LockedFile{},
+		}
+		if t.Path != nil {
+			files, err := resolveFilesFS(ctx, fsys, t.Path)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				if file == LockFile {
+					continue
+				}
+				data, err := fs.ReadFile(fsys, file)
+				if err != nil {
+					return nil, err
+				}
+				sum := sha256.Sum256(data)
+				task.File = append(task.File, &LockedFile{
+					Path:   file,
+					SHA256: hex.EncodeToString(sum[:]),
+				})
+			}
+		}
+		lock.Task = append(lock.Task, task)
+	}
+	return lock, nil
+}
+
+// DecodeLock returns the Lock serialized by WriteLock; returns an error if the schema version is not supported
+func DecodeLock(data []byte) (*Lock, error) {
+	lock := &Lock{}
+	err := json.Unmarshal(data, lock)
+	if err != nil {
+		return nil, err
+	}
+	if lock.Schema != LockSchema {
+		return nil, fmt.Errorf("unsupported lock schema `%v`, expecting `%v`", lock.Schema, LockSchema)
+	}
+	return lock, nil
+}
@@ -0,0 +1,66 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_WriteLock(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644)
+	if err := c.WriteLockRoot(root); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, configuration.LockFile))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	lock, err := configuration.DecodeLock(data)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(lock.Task) != 2 || lock.Task[0].Name != "go" || lock.Task[1].Name != "all" {
+		t.Fatalf("Expecting go and all tasks, got %v", lock.Task)
+	}
+	var paths []string
+	for _, f := range lock.Task[0].File {
+		paths = append(paths, f.Path)
+	}
+	if len(paths) != 3 || paths[0] != "cmd/run.go" || paths[1] != "gen/api.pb.go" || paths[2] != "main.go" {
+		t.Errorf("Expecting go files without vendor, got %v", paths)
+	}
+	if err := c.WriteLockRoot(root); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	again, _ := os.ReadFile(filepath.Join(root, configuration.LockFile))
+	if string(again) != string(data) {
+		t.Errorf("Expecting reproducible lock, got %s", again)
+	}
+}
+
+func TestConfiguration_LockFS(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{Name: "docs", Path: &configuration.Path{Include: []string{"*.md"}}},
+			{Name: "off", Disabled: true, Path: &configuration.Path{Include: []string{"*"}}},
+		},
+	}
+	lock, err := c.LockFS(fstest.MapFS{"a.md": {Data: []byte("a")}, "b.md": {Data: []byte("a")}, "c.go": {}})
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(lock.Task) != 1 || len(lock.Task[0].File) != 2 {
+		t.Fatalf("Expecting 1 task with 2 files, got %v", lock.Task)
+	}
+	if lock.Task[0].File[0].SHA256 != "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb" || lock.Task[0].File[0].SHA256 != lock.Task[0].File[1].SHA256 {
+		t.Errorf("Expecting sha256 of a, got %v", lock.Task[0].File[0].SHA256)
+	}
+	if _, err := configuration.DecodeLock([]byte(`{"schema": 2}`)); err == nil {
+		t.Errorf("Expecting unsupported schema error, got nil")
+	}
+}
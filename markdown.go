@@ -0,0 +1,110 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown returns human readable documentation of Configuration, including the Notes of every script, task, file and plugin; tasks and files are documented with Extends resolved
+func (c *Configuration) Markdown() string {
+	if c == nil {
+		return ""
+	}
+	var b strings.Builder
+	title := c.Name
+	if len(title) == 0 {
+		title = ConfigFile
+	}
+	fmt.Fprintf(&b, "# %s\n", title)
+	if len(c.Description) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", c.Description)
+	}
+	var about []string
+	for _, field := range [][2]string{{"Version", c.Version}, {"Author", c.Author}, {"License", c.License}} {
+		if len(field[1]) > 0 {
+			about = append(about, fmt.Sprintf("- %s: %s\n", field[0], field[1]))
+		}
+	}
+	if len(about) > 0 {
+		fmt.Fprintf(&b, "\n%s", strings.Join(about, ""))
+	}
+	if len(c.Script) > 0 {
+		b.WriteString("\n## Scripts\n")
+		for _, s := range c.Script {
+			if s == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### `%s`\n", s.Name)
+			markdownDeprecated(&b, s.Deprecated)
+			markdownNotes(&b, s.Notes)
+			fmt.Fprintf(&b, "\n- Tasks: %s\n", markdownCode(s.Task))
+		}
+	}
+	if len(c.Task) > 0 {
+		b.WriteString("\n## Tasks\n")
+		for _, t := range c.Task {
+			if t == nil {
+				continue
+			}
+			t = c.resolve(t)
+			fmt.Fprintf(&b, "\n### `%s`\n", t.Name)
+			markdownDeprecated(&b, t.Deprecated)
+			markdownNotes(&b, t.Notes)
+			if t.Path != nil {
+				fmt.Fprintf(&b, "\n- Include: %s\n", markdownCode(t.Path.Include))
+				if len(t.Path.Exclude) > 0 {
+					fmt.Fprintf(&b, "- Exclude: %s\n", markdownCode(t.Path.Exclude))
+				}
+			}
+		}
+	}
+	if len(c.File) > 0 {
+		b.WriteString("\n## Files\n")
+		for _, f := range c.File {
+			if f == nil {
+				continue
+			}
+			f = c.resolveFile(f)
+			fmt.Fprintf(&b, "\n### %s\n", markdownCode(f.Type))
+			markdownNotes(&b, f.Notes)
+			if f.Modify == nil || len(f.Modify.Plugin) == 0 {
+				continue
+			}
+			b.WriteString("\n")
+			for _, plugin := range f.Modify.Plugin {
+				if plugin == nil {
+					continue
+				}
+				fmt.Fprintf(&b, "- Plugin: `%s`", plugin.Path)
+				if len(plugin.Notes) > 0 {
+					fmt.Fprintf(&b, " — %s", plugin.Notes)
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// markdownNotes writes the Notes of an element as its own paragraph, if any
+func markdownNotes(b *strings.Builder, notes string) {
+	if len(strings.TrimSpace(notes)) > 0 {
+		fmt.Fprintf(b, "\n%s\n", strings.TrimSpace(notes))
+	}
+}
+
+// markdownDeprecated writes the Deprecated message of an element as a quote, if any
+func markdownDeprecated(b *strings.Builder, deprecated string) {
+	if len(strings.TrimSpace(deprecated)) > 0 {
+		fmt.Fprintf(b, "\n> Deprecated: %s\n", strings.TrimSpace(deprecated))
+	}
+}
+
+// markdownCode returns the values as comma separated inline code
+func markdownCode(values []string) string {
+	code := make([]string, len(values))
+	for i, value := range values {
+		code[i] = "`" + value + "`"
+	}
+	return strings.Join(code, ", ")
+}
@@ -0,0 +1,33 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Markdown(t *testing.T) {
+	document := `{
+		"name": "lorem",
+		"version": "1.0.0",
+		"task": [{"name": "docs", "notes": "Parses every go file", "path": {"include": ["**/*.go"], "exclude": ["vendor/**"]}}],
+		"script": [{"name": "build", "notes": "Runs on every commit", "task": ["docs"]}],
+		"file": [{"type": ["go"], "notes": "Go sources", "modify": {"plugin": [{"path": "plugin.so", "notes": "Strips build tags"}]}}]
+	}`
+	c := &configuration.Configuration{}
+	if err := json.Unmarshal([]byte(document), c); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	markdown := c.Markdown()
+	for _, expected := range []string{"# lorem\n", "- Version: 1.0.0\n", "### `build`\n\nRuns on every commit\n", "- Tasks: `docs`\n", "Parses every go file\n", "- Exclude: `vendor/**`\n", "### `go`\n\nGo sources\n", "- Plugin: `plugin.so` — Strips build tags\n"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expecting %q, got %v", expected, markdown)
+		}
+	}
+	data, _ := json.Marshal(c)
+	if !strings.Contains(string(data), `"notes":"Strips build tags"`) || !strings.Contains(string(data), `"notes":"Parses every go file"`) {
+		t.Errorf("Expecting notes preserved, got %s", data)
+	}
+}
@@ -0,0 +1,319 @@
+package configuration
+
+import (
+	"path"
+	"strings"
+)
+
+// maxExpansions is the number of patterns the `{a,b}` alternatives of a single pattern may expand to
+const maxExpansions = 1024
+
+// match returns true if the slash separated name matches the pattern; a `**` segment matches any number of directories and `{a,b}` matches either alternative
+func match(pattern string, name string) bool {
+	return compileGlob(pattern).match(name)
+}
+
+// glob contains the segments of every `{a,b}` alternative of a pattern, expanded once to match many names
+type glob [][]string
+
+// compileGlob returns the glob of the pattern; an invalid pattern matches no name
+func compileGlob(pattern string) glob {
+	expanded, err := expandBraces(strings.TrimPrefix(pattern, "./"))
+	if err != nil {
+		return nil
+	}
+	g := make(glob, 0, len(expanded))
+	for _, p := range expanded {
+		g = append(g, strings.Split(p, "/"))
+	}
+	return g
+}
+
+// match returns true if the slash separated name matches any alternative of the glob
+func (g glob) match(name string) bool {
+	segments := strings.Split(strings.TrimPrefix(name, "./"), "/")
+	for _, p := range g {
+		if matchSegments(p, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandBraces returns every pattern of the `{a,b}` alternatives of the pattern, which may nest; an unbalanced brace, or more than maxExpansions patterns, returns path.ErrBadPattern
+func expandBraces(pattern string) ([]string, error) {
+	start, depth := -1, 0
+	var commas []int
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '[':
+			for i++; i < len(pattern) && pattern[i] != ']'; i++ {
+				if pattern[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		case '}':
+			if depth == 0 {
+				return nil, path.ErrBadPattern
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			bounds := append(append([]int{start}, commas...), i)
+			var expanded []string
+			for j := 0; j+1 < len(bounds); j++ {
+				alternatives, err := expandBraces(pattern[:start] + pattern[bounds[j]+1:bounds[j+1]] + pattern[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				expanded = append(expanded, alternatives...)
+				if len(expanded) > maxExpansions {
+					return nil, path.ErrBadPattern
+				}
+			}
+			return expanded, nil
+		}
+	}
+	if depth > 0 {
+		return nil, path.ErrBadPattern
+	}
+	return []string{pattern}, nil
+}
+
+// validPattern returns path.ErrBadPattern if the slash separated pattern has an unbalanced brace or a malformed segment
+func validPattern(pattern string) error {
+	expanded, err := expandBraces(pattern)
+	if err != nil {
+		return err
+	}
+	for _, p := range expanded {
+		for _, segment := range strings.Split(p, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matchSegments returns true if every name segment is matched by the pattern segments
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
+
+// globs contains the compiled patterns of a list, in order, and which of them are `!` negations
+type globs struct {
+	glob   []glob
+	negate []bool
+}
+
+// compileGlobs returns the compiled patterns; with negation a leading `!` makes a pattern a negation, and `\!` matches a leading `!` literally
+func compileGlobs(patterns []string, negation bool) *globs {
+	g := &globs{}
+	for _, pattern := range patterns {
+		negate := negation && strings.HasPrefix(pattern, "!")
+		if negate {
+			pattern = pattern[1:]
+		}
+		g.glob = append(g.glob, compileGlob(pattern))
+		g.negate = append(g.negate, negate)
+	}
+	return g
+}
+
+// any returns true if the slash separated name matches any of the patterns
+func (g *globs) any(name string) bool {
+	for _, p := range g.glob {
+		if p.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// included returns true if the last pattern matching the slash separated name is not a `!` negation, as in gitignore
+func (g *globs) included(name string) bool {
+	included := false
+	for i, p := range g.glob {
+		if g.negate[i] {
+			included = included && !p.match(name)
+		} else {
+			included = included || p.match(name)
+		}
+	}
+	return included
+}
+
+// hasNegation returns true if any of the include patterns is a `!` negation, making their order significant
+func hasNegation(patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			return true
+		}
+	}
+	return false
+}
+
+// positive returns the include patterns that are not `!` negations; negations only ever remove files, so analyses of what a task may process ignore them
+func positive(patterns []string) []string {
+	var list []string
+	for _, pattern := range patterns {
+		if !strings.HasPrefix(pattern, "!") {
+			list = append(list, pattern)
+		}
+	}
+	return list
+}
+
+// covers returns true if every name matched by pattern b is provably matched by pattern a; the analysis is conservative and never touches the filesystem
+func covers(a string, b string) bool {
+	alternatives, errA := expandBraces(strings.TrimPrefix(a, "./"))
+	expanded, errB := expandBraces(strings.TrimPrefix(b, "./"))
+	if errA != nil || errB != nil {
+		return false
+	}
+	for _, eb := range expanded {
+		covered := false
+		for _, ea := range alternatives {
+			if coverSegments(strings.Split(ea, "/"), strings.Split(eb, "/")) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// coverSegments returns true if the segments of pattern a cover every name matched by the segments of pattern b
+func coverSegments(a []string, b []string) bool {
+	if len(a) == 0 {
+		return len(b) == 0
+	}
+	if a[0] == "**" {
+		for i := 0; i <= len(b); i++ {
+			if coverSegments(a[1:], b[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(b) == 0 || b[0] == "**" {
+		return false
+	}
+	if !coverSegment(a[0], b[0]) {
+		return false
+	}
+	return coverSegments(a[1:], b[1:])
+}
+
+// coverSegment returns true if the single segment pattern a matches every segment matched by b
+func coverSegment(a string, b string) bool {
+	if a == b || a == "*" {
+		return true
+	}
+	if !hasMeta(b) {
+		matched, err := path.Match(a, b)
+		return err == nil && matched
+	}
+	if strings.HasPrefix(a, "*") && !hasMeta(a[1:]) {
+		return strings.HasSuffix(b, a[1:])
+	}
+	if strings.HasSuffix(a, "*") && !hasMeta(a[:len(a)-1]) {
+		return strings.HasPrefix(b, a[:len(a)-1])
+	}
+	return false
+}
+
+// hasMeta returns true if the pattern contains any glob meta character
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[\\")
+}
+
+// intersects returns true if some name could be matched by both patterns; the analysis is conservative and never touches the filesystem
+func intersects(a string, b string) bool {
+	alternatives, errA := expandBraces(strings.TrimPrefix(a, "./"))
+	expanded, errB := expandBraces(strings.TrimPrefix(b, "./"))
+	if errA != nil || errB != nil {
+		return true
+	}
+	for _, ea := range alternatives {
+		for _, eb := range expanded {
+			if intersectSegments(strings.Split(ea, "/"), strings.Split(eb, "/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// intersectSegments returns true if the segments of both patterns could match the same name
+func intersectSegments(a []string, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	if len(a) > 0 && a[0] == "**" {
+		return intersectSegments(a[1:], b) || (len(b) > 0 && intersectSegments(a, b[1:]))
+	}
+	if len(b) > 0 && b[0] == "**" {
+		return intersectSegments(a, b[1:]) || (len(a) > 0 && intersectSegments(a[1:], b))
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	return intersectSegment(a[0], b[0]) && intersectSegments(a[1:], b[1:])
+}
+
+// intersectSegment returns true if both single segment patterns could match the same segment
+func intersectSegment(a string, b string) bool {
+	if !hasMeta(a) {
+		matched, err := path.Match(b, a)
+		return err == nil && matched
+	}
+	if !hasMeta(b) {
+		matched, err := path.Match(a, b)
+		return err == nil && matched
+	}
+	if strings.HasPrefix(a, "*") && strings.HasPrefix(b, "*") && !hasMeta(a[1:]) && !hasMeta(b[1:]) {
+		return strings.HasSuffix(a[1:], b[1:]) || strings.HasSuffix(b[1:], a[1:])
+	}
+	if strings.HasSuffix(a, "*") && strings.HasSuffix(b, "*") && !hasMeta(a[:len(a)-1]) && !hasMeta(b[:len(b)-1]) {
+		return strings.HasPrefix(a, b[:len(b)-1]) || strings.HasPrefix(b, a[:len(a)-1])
+	}
+	return true
+}
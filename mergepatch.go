@@ -0,0 +1,39 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ApplyMergePatch applies the JSON Merge Patch (RFC 7386) document to Configuration; objects merge, null removes a member and every other value, arrays included, replaces
+func (c *Configuration) ApplyMergePatch(patch []byte) error {
+	var layer interface{}
+	if err := json.Unmarshal(patch, &layer); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+	document, err := c.document()
+	if err != nil {
+		return err
+	}
+	return c.setDocument(mergePatch(document, layer))
+}
+
+// mergePatch returns the target with the RFC 7386 patch applied
+func mergePatch(target, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	for key, value := range members {
+		if value == nil {
+			delete(object, key)
+		} else {
+			object[key] = mergePatch(object[key], value)
+		}
+	}
+	return object
+}
@@ -0,0 +1,46 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_ApplyMergePatch(t *testing.T) {
+	c := &configuration.Configuration{
+		Name:    "lorem",
+		Version: "1.0.0",
+		Task:    []*configuration.Task{{Name: "docs"}},
+		File: []*configuration.File{{
+			Type:  []string{"go"},
+			Parse: &configuration.Parse{Source: true},
+		}},
+		Var: map[string]string{"src": "src", "out": "docs"},
+	}
+	err := c.ApplyMergePatch([]byte(`{
+		"version": null,
+		"file": [{"type": ["go"], "parse": {"source": false}}],
+		"var": {"out": null, "dist": "dist"}
+	}`))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "lorem" || len(c.Version) > 0 || len(c.Task) != 1 {
+		t.Errorf("Expecting name kept and version removed, got %+v", c)
+	}
+	if c.File[0].Parse.Source {
+		t.Errorf("Expecting parse.source false, got true")
+	}
+	if len(c.Var) != 2 || c.Var["src"] != "src" || c.Var["dist"] != "dist" {
+		t.Errorf("Expecting merged vars, got %v", c.Var)
+	}
+	if err := c.ApplyMergePatch([]byte(`{"name": 1}`)); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if err := c.ApplyMergePatch([]byte(`[]`)); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	if c.Name != "lorem" {
+		t.Errorf("Expecting unchanged Configuration, got %v", c.Name)
+	}
+}
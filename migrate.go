@@ -0,0 +1,265 @@
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigSchema constant for the current ConfigFile schema version; incremented whenever a migration is added
+	ConfigSchema = 1
+	// BackupSuffix constant for the suffix of the backup MigrateAndWrite and WithBackups keep of a configuration file
+	BackupSuffix = ".bak"
+)
+
+// migration upgrades a document by exactly one schema version
+type migration struct {
+	description string
+	migrate     func(document *yaml.Node)
+}
+
+// migrations contains every migration in order; migrations[i] upgrades schema version i to i+1
+var migrations = []*migration{
+	{"single values of list definitions become lists", func(document *yaml.Node) {
+		for _, list := range []string{
+			"script/*/task",
+			"task/*/path/include",
+			"task/*/path/exclude",
+			"file/*/type",
+			"file/*/parse/exclude",
+			"file/*/audit/*/include",
+			"file/*/audit/*/exclude",
+			"definitions/task/*/path/include",
+			"definitions/task/*/path/exclude",
+			"definitions/file/*/type",
+			"definitions/file/*/parse/exclude",
+		} {
+			visitNode(document, strings.Split(list, "/"), func(node *yaml.Node) {
+				if node.Kind == yaml.ScalarNode && node.ShortTag() != "!!null" {
+					scalar := *node
+					*node = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{&scalar}}
+				}
+			})
+		}
+	}},
+}
+
+// ValidateSchemaVersion returns an error if ConfigFile was written for a newer schema than this package supports
+func (c *Configuration) ValidateSchemaVersion() error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if c.SchemaVersion > ConfigSchema {
+		return newError(CodeUnsupported, pointer("schemaVersion"), "`%s` schema version `%v` is newer than the supported version `%v`", ConfigFile, c.SchemaVersion, ConfigSchema)
+	}
+	return nil
+}
+
+// Migrate returns the JSON document upgraded to ConfigSchema; unknown fields and field ordering are preserved
+func Migrate(document []byte) ([]byte, error) {
+	node, err := decodeNode(document, JSON)
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top-level value must be an object")
+	}
+	version := 0
+	if v := mappingValue(node, "schemaVersion"); v != nil {
+		version, err = strconv.Atoi(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("schema version `%s` is not a number", v.Value)
+		}
+	}
+	if version > ConfigSchema {
+		return nil, fmt.Errorf("schema version `%v` is newer than the supported version `%v`", version, ConfigSchema)
+	}
+	if version == ConfigSchema {
+		return document, nil
+	}
+	for _, m := range migrations[version:] {
+		m.migrate(node)
+	}
+	setMappingValue(node, "schemaVersion", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(ConfigSchema)})
+	return encodeNode(node, JSON)
+}
+
+// PreviewMigration returns the line diff Migrate would apply to ConfigFile, or "" if ConfigFile is already current; nothing is written
+func PreviewMigration() (string, error) {
+	return previewMigration(ConfigFile)
+}
+
+// previewMigration returns the line diff Migrate would apply to the provided path
+func previewMigration(path string) (string, error) {
+	document, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	migrated, err := Migrate(document)
+	if err != nil {
+		return "", err
+	}
+	if string(migrated) == string(document) {
+		return "", nil
+	}
+	return lineDiff(path, string(document), string(migrated)), nil
+}
+
+// MigrateAndWrite upgrades ConfigFile to ConfigSchema in place; the original is kept with BackupSuffix and the replacement is written atomically
+func MigrateAndWrite() error {
+	return migrateAndWrite(ConfigFile)
+}
+
+// migrateAndWrite upgrades the provided path in place, keeping a backup of the original, holding its exclusive lock throughout
+func migrateAndWrite(path string) error {
+	unlock, err := acquireLock(newOptions(nil).cacheDir(), path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	document, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	migrated, err := Migrate(document)
+	if err != nil {
+		return err
+	}
+	if string(migrated) == string(document) {
+		return nil
+	}
+	if err := writeAtomic(path+BackupSuffix, document, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return writeAtomic(path, migrated, info.Mode().Perm())
+}
+
+// writeAtomic writes data to a temporary file beside path and renames it over path, so readers never observe a partial file
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	temp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(temp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// mappingValue returns the value of key within the mapping node, or nil if absent
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key within the mapping node, or inserts key first if absent
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value}, node.Content...)
+}
+
+// visitNode calls visit for every node at the path of mapping keys; a `*` token visits every sequence element
+func visitNode(node *yaml.Node, tokens []string, visit func(node *yaml.Node)) {
+	if len(tokens) == 0 {
+		visit(node)
+		return
+	}
+	switch {
+	case tokens[0] == "*" && node.Kind == yaml.SequenceNode:
+		for _, child := range node.Content {
+			visitNode(child, tokens[1:], visit)
+		}
+	case node.Kind == yaml.MappingNode:
+		if child := mappingValue(node, tokens[0]); child != nil {
+			visitNode(child, tokens[1:], visit)
+		}
+	}
+}
+
+// lineEdit is one step of a line diff: a line of before kept or removed, or a line of after added
+type lineEdit struct {
+	op    byte
+	index int
+}
+
+// diffLines returns the edits turning before into after along their longest common subsequence of lines; op is ` ` for a kept line and `-` for a removed line, both indexing before, or `+` for an added line indexing after
+func diffLines(before, after []string) []lineEdit {
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	var edits []lineEdit
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			edits = append(edits, lineEdit{' ', i})
+			i++
+			j++
+		case i < len(before) && (j == len(after) || common[i+1][j] >= common[i][j+1]):
+			edits = append(edits, lineEdit{'-', i})
+			i++
+		default:
+			edits = append(edits, lineEdit{'+', j})
+			j++
+		}
+	}
+	return edits
+}
+
+// lineDiff returns a line based diff of a and b, prefixing removed lines with `-`, added lines with `+` and unchanged lines with a space
+func lineDiff(name string, a, b string) string {
+	before, after := strings.Split(a, "\n"), strings.Split(b, "\n")
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", name, name)
+	for _, edit := range diffLines(before, after) {
+		if edit.op == '+' {
+			fmt.Fprintf(&out, "+%s\n", after[edit.index])
+		} else {
+			fmt.Fprintf(&out, "%c%s\n", edit.op, before[edit.index])
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,96 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+const legacy = `{
+	"name": "lorem",
+	"task": [
+		{
+			"name": "ipsum",
+			"path": {
+				"include": "*.go"
+			}
+		}
+	],
+	"script": [
+		{
+			"name": "build",
+			"task": "ipsum"
+		}
+	],
+	"file": [
+		{
+			"type": "go",
+			"parse": {
+				"comment": {
+					"line": "//"
+				}
+			}
+		}
+	]
+}`
+
+func TestMigrate(t *testing.T) {
+	migrated, err := configuration.Migrate([]byte(legacy))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	c := &configuration.Configuration{}
+	if err := json.Unmarshal(migrated, c); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.SchemaVersion != configuration.ConfigSchema {
+		t.Errorf("Expecting schema version %v, got %v", configuration.ConfigSchema, c.SchemaVersion)
+	}
+	if errs := c.Validate(); errs != nil {
+		t.Errorf("Expecting nil, got %v", errs)
+	}
+	again, err := configuration.Migrate(migrated)
+	if err != nil || string(again) != string(migrated) {
+		t.Errorf("Expecting unchanged document, got %v %s", err, again)
+	}
+	_, err = configuration.Migrate([]byte(`{"schemaVersion": 99}`))
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestMigrateAndWrite(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	os.WriteFile(configuration.ConfigFile, []byte(legacy), 0644)
+	preview, err := configuration.PreviewMigration()
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.Contains(preview, "-\t\t\t\t\"include\": \"*.go\"") || !strings.Contains(preview, "+\t\"schemaVersion\": 1,") {
+		t.Errorf("Expecting schema version diff, got %v", preview)
+	}
+	data, _ := os.ReadFile(configuration.ConfigFile)
+	if string(data) != legacy {
+		t.Errorf("Expecting preview to leave file unchanged")
+	}
+	if err := configuration.MigrateAndWrite(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	backup, _ := os.ReadFile(configuration.ConfigFile + configuration.BackupSuffix)
+	if string(backup) != legacy {
+		t.Errorf("Expecting backup of original, got %s", backup)
+	}
+	c := &configuration.Configuration{}
+	if err := c.Load(); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+	preview, err = configuration.PreviewMigration()
+	if err != nil || preview != "" {
+		t.Errorf("Expecting empty preview, got %v %v", err, preview)
+	}
+}
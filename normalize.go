@@ -0,0 +1,68 @@
+package configuration
+
+import (
+	"reflect"
+	"strings"
+)
+
+// trimFields contains the pointer patterns of every string Normalize trims: names, task references, file types and path patterns; other strings such as regular expressions and comment syntax change how files are rewritten when trimmed
+var trimFields = append([]string{"**/name", "**/script/*/task/*", "**/type/*"}, patternFields...)
+
+// Normalize rewrites Configuration into its canonical form for diffing and hashing: names, task references, file types and path patterns are trimmed, file types lowercased, repeated file types and path patterns removed, then tasks and scripts sorted by name, files by name or types, and every list whose order Equal ignores sorted
+func (c *Configuration) Normalize() {
+	if c == nil {
+		return
+	}
+	c.visitPaths(trimFields, func(p string, value reflect.Value) {
+		value.SetString(strings.TrimSpace(value.String()))
+	})
+	tasks := append([]*Task{}, c.Task...)
+	files := append([]*File{}, c.File...)
+	if c.Definitions != nil {
+		tasks = append(tasks, c.Definitions.Task...)
+		files = append(files, c.Definitions.File...)
+	}
+	for _, profile := range c.Profile {
+		if profile != nil {
+			tasks = append(tasks, profile.Task...)
+			files = append(files, profile.File...)
+		}
+	}
+	for _, t := range tasks {
+		if t != nil && t.Path != nil {
+			if !hasNegation(t.Path.Include) {
+				t.Path.Include = dedupe(t.Path.Include)
+			}
+			t.Path.Exclude = dedupe(t.Path.Exclude)
+		}
+	}
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		for i, fileType := range f.Type {
+			f.Type[i] = strings.ToLower(fileType)
+		}
+		f.Type = dedupe(f.Type)
+	}
+	for _, project := range c.Projects {
+		if project != nil {
+			project.Normalize()
+		}
+	}
+	c.canonical()
+}
+
+// dedupe returns the values without repetitions, keeping the first occurrence of each
+func dedupe(values []string) []string {
+	var unique []string
+	for _, value := range values {
+		if !contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	if values != nil && unique == nil {
+		return values
+	}
+	return unique
+}
@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_Normalize(t *testing.T) {
+	c := &configuration.Configuration{
+		Name: " lorem ",
+		Task: []*configuration.Task{
+			{Name: "lint", Path: &configuration.Path{Include: []string{"*.go ", "*.go", "*.md"}}},
+			{Name: "docs"},
+		},
+		Script: []*configuration.Script{{Name: "b"}, {Name: "a"}},
+		File: []*configuration.File{
+			{Type: []string{"MD"}},
+			{Type: []string{"ts", "Go", "go"}},
+		},
+	}
+	c.Normalize()
+	expected := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{Name: "docs"},
+			{Name: "lint", Path: &configuration.Path{Include: []string{"*.go", "*.md"}}},
+		},
+		Script: []*configuration.Script{{Name: "a"}, {Name: "b"}},
+		File: []*configuration.File{
+			{Type: []string{"go", "ts"}},
+			{Type: []string{"md"}},
+		},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("Expecting %+v, got %+v", expected, c)
+	}
+	c = &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type:   []string{" go"},
+				Parse:  &configuration.Parse{Comment: &core.Comment{Line: "// "}},
+				Modify: &configuration.Modify{Regex: []*core.RegularExpression{{Find: "a ", Replace: " b"}}},
+			},
+		},
+	}
+	c.Normalize()
+	if f := c.File[0]; f.Type[0] != "go" || f.Parse.Comment.Line != "// " || f.Modify.Regex[0].Find != "a " || f.Modify.Regex[0].Replace != " b" {
+		t.Errorf("Expecting only the type trimmed, got %v %v %v", f.Type, f.Parse.Comment, f.Modify.Regex[0])
+	}
+}
@@ -0,0 +1,180 @@
+package configuration
+
+import (
+	"context"
+	"net/http"
+)
+
+// Option configures how Configuration is loaded, validated and written
+type Option func(*options)
+
+// options contains every setting an Option may change
+type options struct {
+	maxErrors int
+	failFast  bool
+	envPrefix string
+	format    Format
+	verify    bool
+	tolerant  bool
+	root      string
+	strict    bool
+	schema    string
+	validate  bool
+	budget    int
+	backups   int
+	ctx       context.Context
+	header    http.Header
+	authHosts []string
+	cache     string
+	sources   *[]string
+}
+
+// newOptions returns options with every Option applied in order
+func newOptions(option []Option) *options {
+	o := &options{}
+	for _, apply := range option {
+		apply(o)
+	}
+	return o
+}
+
+// limit returns the maximum number of validation errors to collect; zero means unbounded
+func (o *options) limit() int {
+	if o.failFast {
+		return 1
+	}
+	return o.maxErrors
+}
+
+// context returns the context of LoadContext or ValidateContext, or the background context
+func (o *options) context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
+
+// cacheDir returns the directory remote resources are cached in: the WithCacheDir directory, CacheDir or the UserDirs cache directory
+func (o *options) cacheDir() string {
+	if len(o.cache) > 0 {
+		return o.cache
+	}
+	if len(CacheDir) > 0 {
+		return CacheDir
+	}
+	return UserDirs.Cache
+}
+
+// withContext bounds loading and validation by ctx; set by LoadContext and ValidateContext
+func withContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// withSources records the path of every local file decoded while loading into sources; set by WatchPath
+func withSources(sources *[]string) Option {
+	return func(o *options) {
+		o.sources = sources
+	}
+}
+
+// WithMaxErrors stops validation once n errors are found; zero or less means unbounded
+func WithMaxErrors(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxErrors = n
+	}
+}
+
+// WithFailFast stops validation at the first error, for callers that only need a yes or no answer
+func WithFailFast() Option {
+	return func(o *options) {
+		o.failFast = true
+	}
+}
+
+// WithEnvPrefix restricts environment variable references to names starting with prefix, such as `EMITS_`
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithFormat selects the Format Write encodes with instead of JSON, or WriteToPath instead of the Codec of the path extension; the file Write creates is ConfigFile with the extension of the Format
+func WithFormat(format Format) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithVerify re-reads the file after Write and returns an error if it does not round-trip, guarding against partial writes and codec bugs
+func WithVerify() Option {
+	return func(o *options) {
+		o.verify = true
+	}
+}
+
+// WithTolerant decodes JSON with comments and trailing commas when loading, so hand-edited files can be annotated
+func WithTolerant() Option {
+	return func(o *options) {
+		o.tolerant = true
+	}
+}
+
+// WithProjectRoot rewrites absolute paths under root into relative paths on Write, and relative paths into absolute paths under root on Load, keeping machine specific paths out of committed files
+func WithProjectRoot(root string) Option {
+	return func(o *options) {
+		o.root = root
+	}
+}
+
+// WithStrict fails loading when the document holds a field Configuration does not declare, reporting each unknown key and its JSON pointer
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithSchemaValidation fails loading when a value of the document differs in type from the embedded JSON Schema, reporting each value and its JSON pointer before unmarshalling mangles it
+func WithSchemaValidation() Option {
+	return func(o *options) {
+		o.validate = true
+	}
+}
+
+// WithSchema writes the `$schema` URL into the encoded document, enabling editor completion and validation; a `$schema` already present on Configuration is replaced
+func WithSchema(url string) Option {
+	return func(o *options) {
+		o.schema = url
+	}
+}
+
+// WithStatBudget stops ValidateFilesystem after n files are stat'ed or walked, reporting the skipped checks as a warning; zero or less means unbounded
+func WithStatBudget(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.budget = n
+	}
+}
+
+// WithBackups keeps the n most recent versions of a file before Write replaces it, as BackupSuffix then BackupSuffix followed by `.1` and so on; writes that do not change the file keep no backup
+func WithBackups(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.backups = n
+	}
+}
+
+// WithCacheDir keeps the local copy of every remote extends, include and source in dir instead of CacheDir or the UserDirs cache directory
+func WithCacheDir(dir string) Option {
+	return func(o *options) {
+		o.cache = dir
+	}
+}
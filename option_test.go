@@ -0,0 +1,69 @@
+package configuration_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func brokenConfiguration() *configuration.Configuration {
+	c := &configuration.Configuration{}
+	for i := 0; i < 100; i++ {
+		c.Task = append(c.Task, &configuration.Task{})
+	}
+	return c
+}
+
+func TestWithMaxErrors(t *testing.T) {
+	err := brokenConfiguration().Validate()
+	if len(err) < 100 {
+		t.Errorf("Expecting at least 100 errors, got %v", len(err))
+	}
+	err = brokenConfiguration().Validate(configuration.WithMaxErrors(10))
+	if len(err) != 10 {
+		t.Errorf("Expecting 10 errors, got %v", len(err))
+	}
+	err = brokenConfiguration().Validate(configuration.WithMaxErrors(-1))
+	if len(err) < 100 {
+		t.Errorf("Expecting at least 100 errors, got %v", len(err))
+	}
+}
+
+func TestWithFailFast(t *testing.T) {
+	err := brokenConfiguration().Validate(configuration.WithFailFast())
+	if len(err) != 1 {
+		t.Errorf("Expecting 1 error, got %v", len(err))
+	}
+	err = brokenConfiguration().Validate(configuration.WithMaxErrors(10), configuration.WithFailFast())
+	if len(err) != 1 {
+		t.Errorf("Expecting 1 error, got %v", len(err))
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	url := "https://example.com/emits.schema.json"
+	c := &configuration.Configuration{
+		Name: "lorem",
+	}
+	var b bytes.Buffer
+	if err := c.Encode(&b, configuration.WithSchema(url)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "{\n\t\"$schema\": \""+url+"\"") || len(c.Schema) != 0 {
+		t.Errorf("Expecting $schema written without changing Configuration, got %v", b.String())
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.LoadReader(&b, configuration.WithStrict()); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if loaded.Schema != url {
+		t.Errorf("Expecting %v, got %v", url, loaded.Schema)
+	}
+	b.Reset()
+	loaded.Encode(&b)
+	if !strings.Contains(b.String(), url) {
+		t.Errorf("Expecting $schema preserved, got %v", b.String())
+	}
+}
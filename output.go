@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// outputVariables contains every variable an Output template may reference
+var outputVariables = []string{"path", "dir", "name", "ext", "type"}
+
+// outputReferences returns the variable references of an Output template in order; returns an error on unbalanced braces
+func outputReferences(template string) ([]string, error) {
+	var references []string
+	rest := template
+	for {
+		start := strings.Index(rest, "{{")
+		end := strings.Index(rest, "}}")
+		if start < 0 && end < 0 {
+			return references, nil
+		}
+		if start < 0 || end < start {
+			return nil, fmt.Errorf("unbalanced `{{` `}}`")
+		}
+		references = append(references, rest[start+2:end])
+		rest = rest[end+2:]
+	}
+}
+
+// OutputPath returns the Output template expanded for the slash separated file; `{{path}}` is the file without extension, `{{dir}}` its directory, `{{name}}` its base name without extension, `{{ext}}` its extension and `{{type}}` its File type
+func (f *File) OutputPath(file string) (string, error) {
+	if f == nil {
+		return "", newError(CodeMissing, "", "file definition is null")
+	}
+	references, err := outputReferences(f.Output)
+	if err != nil {
+		return "", err
+	}
+	ext := path.Ext(file)
+	values := map[string]string{
+		"path": strings.TrimSuffix(file, ext),
+		"dir":  path.Dir(file),
+		"name": strings.TrimSuffix(path.Base(file), ext),
+		"ext":  strings.TrimPrefix(ext, "."),
+		"type": strings.TrimPrefix(ext, "."),
+	}
+	var pairs []string
+	for _, reference := range references {
+		value, ok := values[reference]
+		if !ok {
+			return "", fmt.Errorf("unknown output variable `%s`", reference)
+		}
+		pairs = append(pairs, "{{"+reference+"}}", value)
+	}
+	return path.Clean(strings.NewReplacer(pairs...).Replace(f.Output)), nil
+}
+
+// ValidateOutput returns all known errors of the File Output template; templates mapping every file of a directory to one output or overwriting the source file are errors
+func (f *File) ValidateOutput() []error {
+	if f == nil {
+		return nil
+	}
+	var errors []error
+	if len(f.Output) == 0 {
+		return errors
+	}
+	fileType := strings.Join(f.Type, ",")
+	references, err := outputReferences(f.Output)
+	if err != nil {
+		return append(errors, newError(CodeInvalid, pointer("output"), "`%s` file output definition is invalid: %v", fileType, err))
+	}
+	for _, reference := range references {
+		if !contains(outputVariables, reference) {
+			errors = append(errors, newError(CodeUnknown, pointer("output"), "`%s` file output definition references unknown variable `%s`", fileType, reference).suggest(reference, outputVariables))
+		}
+	}
+	if errors != nil {
+		return errors
+	}
+	if !contains(references, "path") && !contains(references, "name") {
+		return append(errors, newError(CodeConflict, pointer("output"), "`%s` file output definition references neither `{{path}}` nor `{{name}}`; every file would emit to the same output", fileType))
+	}
+	for _, t := range f.Type {
+		source := "dir/name." + t
+		if output, err := f.OutputPath(source); err == nil && output == source {
+			return append(errors, newError(CodeConflict, pointer("output"), "`%s` file output definition would overwrite the source file", fileType))
+		}
+	}
+	return errors
+}
+
+// lintOutput returns the collision warning of an Output template naming files without their directory; used by File.Lint
+func (f *File) lintOutput() []error {
+	references, err := outputReferences(f.Output)
+	if err != nil || len(references) == 0 || contains(references, "path") || contains(references, "dir") {
+		return nil
+	}
+	return []error{newWarning(CodeConflict, pointer("output"), "`%s` file output definition references neither `{{path}}` nor `{{dir}}`; files of the same name in different directories emit to the same output", strings.Join(f.Type, ","))}
+}
@@ -0,0 +1,106 @@
+package configuration_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestFile_OutputPath(t *testing.T) {
+	f := &configuration.File{
+		Type:   []string{"go"},
+		Output: "{{dir}}/{{name}}.emits.json",
+	}
+	output, err := f.OutputPath("cmd/run.go")
+	if err != nil || output != "cmd/run.emits.json" {
+		t.Errorf("Expecting cmd/run.emits.json, got %v %v", output, err)
+	}
+	f.Output = "out/{{path}}.{{ext}}.json"
+	output, err = f.OutputPath("main.go")
+	if err != nil || output != "out/main.go.json" {
+		t.Errorf("Expecting out/main.go.json, got %v %v", output, err)
+	}
+	f.Output = "{{dir}}/{{nme}}.json"
+	_, err = f.OutputPath("main.go")
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestFile_ValidateOutput(t *testing.T) {
+	tests := map[string]string{
+		"{{dir}}/{{name}}.json": "",
+		"{{dir}}/{{name}.json":  "invalid",
+		"{{dir}}/{{nme}}.json":  "did you mean `name`?",
+		"{{dir}}/index.json":    "same output",
+		"{{dir}}/{{name}}.go":   "overwrite",
+	}
+	for template, expected := range tests {
+		f := &configuration.File{
+			Type:   []string{"go"},
+			Output: template,
+		}
+		errors := f.ValidateOutput()
+		if len(expected) == 0 {
+			if len(errors) != 0 {
+				t.Errorf("Expecting no errors for %v, got %v", template, errors)
+			}
+			continue
+		}
+		if len(errors) != 1 || !strings.Contains(errors[0].Error(), expected) {
+			t.Errorf("Expecting %v for %v, got %v", expected, template, errors)
+		}
+	}
+}
+
+func TestFile_LintOutput(t *testing.T) {
+	f := &configuration.File{
+		Type:   []string{"go"},
+		Output: "out/{{name}}.json",
+	}
+	warnings := f.Lint()
+	if len(warnings) != 1 || configuration.Pointer(warnings[0]) != "/output" || configuration.ErrorSeverity(warnings[0]) != configuration.SeverityWarning {
+		t.Errorf("Expecting output collision warning, got %v", warnings)
+	}
+	f.Output = "out/{{path}}.json"
+	if warnings = f.Lint(); len(warnings) != 0 {
+		t.Errorf("Expecting no warnings, got %v", warnings)
+	}
+}
+
+func TestPlan_Output(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "all",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"all"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type:   []string{"go"},
+				Output: "{{dir}}/{{name}}.emits.json",
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+	plan, err := c.PlanFS("build", fstest.MapFS{"cmd/run.go": {}})
+	if err != nil || plan.Step[0].File[0].Output != "cmd/run.emits.json" {
+		t.Errorf("Expecting cmd/run.emits.json, got %v", err)
+	}
+}
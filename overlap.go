@@ -0,0 +1,150 @@
+package configuration
+
+// Overlap contains two tasks of a Script whose scopes intersect, and the patterns or files they share
+type Overlap struct {
+	Script  string
+	Task    [2]string
+	Pattern [][2]string
+	File    []string
+}
+
+// OverlapReport returns every pair of tasks within the same Script whose include patterns may match the same files
+func (c *Configuration) OverlapReport() []*Overlap {
+	if c == nil {
+		return nil
+	}
+	var overlaps []*Overlap
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		overlaps = append(overlaps, c.scriptOverlaps(s)...)
+	}
+	return overlaps
+}
+
+// OverlapReportRoot returns every pair of tasks within the same Script matching the same files under root
+func (c *Configuration) OverlapReportRoot(root string) ([]*Overlap, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	var overlaps []*Overlap
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		tasks := c.scriptTasks(s)
+		files := make([][]string, len(tasks))
+		for i, t := range tasks {
+			var err error
+			files[i], err = resolveFiles(root, t.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for i := range tasks {
+			for j := i + 1; j < len(tasks); j++ {
+				var shared []string
+				for _, file := range files[j] {
+					if contains(files[i], file) {
+						shared = append(shared, file)
+					}
+				}
+				if len(shared) > 0 {
+					overlaps = append(overlaps, &Overlap{
+						Script: s.Name,
+						Task:   [2]string{tasks[i].Name, tasks[j].Name},
+						File:   shared,
+					})
+				}
+			}
+		}
+	}
+	return overlaps, nil
+}
+
+// ValidateDisjoint returns an error for every overlap of a Script requiring disjoint task scopes
+func (s *Script) ValidateDisjoint(c *Configuration) []error {
+	if s == nil {
+		return nil
+	}
+	var errors []error
+	if !s.Disjoint {
+		return errors
+	}
+	for _, overlap := range c.scriptOverlaps(s) {
+		errors = append(errors, newError(CodeConflict, pointer("disjoint"), "`%s` script requires disjoint tasks but `%s` (`%s`) and `%s` (`%s`) overlap", s.Name, overlap.Task[0], overlap.Pattern[0][0], overlap.Task[1], overlap.Pattern[0][1]))
+	}
+	return errors
+}
+
+// lintOverlaps returns a warning for every overlap of a Script not requiring disjoint task scopes; used by Lint
+func (c *Configuration) lintOverlaps() []error {
+	var warnings []error
+	for i, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if s.Disjoint {
+			continue
+		}
+		for _, overlap := range c.scriptOverlaps(s) {
+			warnings = append(warnings, newWarning(CodeOverlap, pointer("script", i, "task"), "`%s` script tasks `%s` (`%s`) and `%s` (`%s`) may process the same files", s.Name, overlap.Task[0], overlap.Pattern[0][0], overlap.Task[1], overlap.Pattern[0][1]))
+		}
+	}
+	return warnings
+}
+
+// scriptOverlaps returns every pair of tasks within the Script whose include patterns may intersect after excludes
+func (c *Configuration) scriptOverlaps(s *Script) []*Overlap {
+	var overlaps []*Overlap
+	tasks := c.scriptTasks(s)
+	for i := range tasks {
+		for j := i + 1; j < len(tasks); j++ {
+			var patterns [][2]string
+			for _, a := range positive(tasks[i].Path.Include) {
+				for _, b := range positive(tasks[j].Path.Include) {
+					if intersects(a, b) && !coveredByAny(tasks[i].Path.Exclude, b) && !coveredByAny(tasks[j].Path.Exclude, a) {
+						patterns = append(patterns, [2]string{a, b})
+					}
+				}
+			}
+			if len(patterns) > 0 {
+				overlaps = append(overlaps, &Overlap{
+					Script:  s.Name,
+					Task:    [2]string{tasks[i].Name, tasks[j].Name},
+					Pattern: patterns,
+				})
+			}
+		}
+	}
+	return overlaps
+}
+
+// scriptTasks returns the known tasks of Script with Extends resolved, skipping duplicates, disabled tasks and tasks without a path
+func (c *Configuration) scriptTasks(s *Script) []*Task {
+	var tasks []*Task
+	var seen []string
+	for _, name := range s.Task {
+		t := c.FindTask(name)
+		if t == nil || contains(seen, name) {
+			continue
+		}
+		seen = append(seen, name)
+		t = c.resolve(t)
+		if t.Path != nil && !t.Disabled {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// coveredByAny returns true if any of the patterns covers the pattern
+func coveredByAny(patterns []string, pattern string) bool {
+	for _, p := range patterns {
+		if covers(p, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func overlapConfiguration() *configuration.Configuration {
+	return &configuration.Configuration{
+		Description: "overlap",
+		Version:     "1.0.0",
+		Task: []*configuration.Task{
+			{
+				Name: "go",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+				},
+			},
+			{
+				Name: "cmd",
+				Path: &configuration.Path{
+					Include: []string{"cmd/*"},
+				},
+			},
+			{
+				Name: "docs",
+				Path: &configuration.Path{
+					Include: []string{"docs/**/*.md"},
+				},
+			},
+			{
+				Name: "web",
+				Path: &configuration.Path{
+					Include: []string{"**/*.js"},
+					Exclude: []string{"cmd/**"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"go", "cmd", "docs", "web"},
+			},
+		},
+	}
+}
+
+func TestConfiguration_OverlapReport(t *testing.T) {
+	c := overlapConfiguration()
+	overlaps := c.OverlapReport()
+	if len(overlaps) != 1 {
+		t.Fatalf("Expecting 1 overlap, got %v", overlaps)
+	}
+	if overlaps[0].Task != [2]string{"go", "cmd"} || overlaps[0].Pattern[0] != [2]string{"**/*.go", "cmd/*"} {
+		t.Errorf("Expecting go and cmd overlap, got %v", overlaps[0])
+	}
+	if len(c.Lint()) != 1 {
+		t.Errorf("Expecting 1 warning, got %v", c.Lint())
+	}
+	c.Script[0].Disjoint = true
+	if len(c.Script[0].ValidateDisjoint(c)) != 1 {
+		t.Errorf("Expecting 1 error, got %v", c.Script[0].ValidateDisjoint(c))
+	}
+	if c.Lint() != nil {
+		t.Errorf("Expecting nil, got %v", c.Lint())
+	}
+	c.Task[1].Disabled = true
+	if overlaps := c.OverlapReport(); len(overlaps) != 0 {
+		t.Errorf("Expecting no overlap of a disabled task, got %v", overlaps)
+	}
+	if errors := c.Script[0].ValidateDisjoint(c); len(errors) != 0 {
+		t.Errorf("Expecting no errors, got %v", errors)
+	}
+}
+
+func TestConfiguration_OverlapReportRoot(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	overlaps, err := c.OverlapReportRoot(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(overlaps) != 1 || len(overlaps[0].File) != 3 {
+		t.Errorf("Expecting 3 shared files, got %v", overlaps)
+	}
+}
@@ -0,0 +1,178 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Param contains all the options used to establish a parameter on Task or Script
+type Param struct {
+	Name     string `json:"name,omitempty"`
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// FindParam returns the Param if found or nil if not found
+func (t *Task) FindParam(name string) *Param {
+	if t == nil {
+		return nil
+	}
+	return findParam(t.Param, name)
+}
+
+// FindParam returns the Param if found or nil if not found
+func (s *Script) FindParam(name string) *Param {
+	if s == nil {
+		return nil
+	}
+	return findParam(s.Param, name)
+}
+
+// ValidateParams returns all known errors of the Script Param definitions against the Param definitions of the referenced tasks
+func (s *Script) ValidateParams(c *Configuration) []error {
+	if s == nil {
+		return nil
+	}
+	var errors []error
+	var tasks []*Task
+	for _, name := range s.Task {
+		if t := c.FindTask(name); t != nil {
+			tasks = append(tasks, c.resolve(t))
+		}
+	}
+	errors = append(errors, validateParams("script", s.Name, s.Param)...)
+	for i, param := range s.Param {
+		if param == nil {
+			continue
+		}
+		if len(param.Name) == 0 {
+			continue
+		}
+		declared := false
+		for _, t := range tasks {
+			if t.FindParam(param.Name) != nil {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			errors = append(errors, newError(CodeUnknown, pointer("param", i, "name"), "`%s` script param `%s` is not declared by any of its tasks", s.Name, param.Name))
+		}
+	}
+	for _, t := range tasks {
+		for _, param := range t.Param {
+			if param == nil {
+				continue
+			}
+			if param.Required && len(param.Default) == 0 && s.FindParam(param.Name) == nil {
+				errors = append(errors, newError(CodeRequired, pointer("param"), "`%s` script does not forward required `%s` param of `%s` task", s.Name, param.Name, t.Name))
+			}
+		}
+	}
+	return errors
+}
+
+// Arguments returns the parameter values forwarded to each Task of Script; values resolve from arguments, then Script defaults, then Task defaults
+func (s *Script) Arguments(c *Configuration, arguments map[string]string) (map[string]map[string]string, error) {
+	if s == nil {
+		return nil, newError(CodeMissing, "", "script definition is null")
+	}
+	var names []string
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if s.FindParam(name) == nil {
+			return nil, fmt.Errorf("`%s` script has no `%s` param", s.Name, name)
+		}
+	}
+	values := map[string]string{}
+	for _, param := range s.Param {
+		if param == nil {
+			continue
+		}
+		value, ok := arguments[param.Name]
+		if !ok {
+			if param.Required && len(param.Default) == 0 {
+				return nil, fmt.Errorf("`%s` script missing required `%s` param", s.Name, param.Name)
+			}
+			if len(param.Default) == 0 {
+				continue
+			}
+			value = param.Default
+		}
+		values[param.Name] = value
+	}
+	forwarded := map[string]map[string]string{}
+	for _, name := range s.Task {
+		t := c.FindTask(name)
+		if t == nil {
+			return nil, fmt.Errorf("`%s` script referencing unknown `%s` task definition", s.Name, name)
+		}
+		t = c.resolve(t)
+		forwarded[t.Name] = map[string]string{}
+		for _, param := range t.Param {
+			if param == nil {
+				continue
+			}
+			value, ok := values[param.Name]
+			if !ok {
+				if param.Required && len(param.Default) == 0 {
+					return nil, fmt.Errorf("`%s` task missing required `%s` param", t.Name, param.Name)
+				}
+				value = param.Default
+			}
+			forwarded[t.Name][param.Name] = value
+		}
+	}
+	return forwarded, nil
+}
+
+// validateParams returns empty and duplicate Param name errors for the named element
+func validateParams(element string, name string, params []*Param) []error {
+	var errors []error
+	var seen []string
+	for i, param := range params {
+		if param == nil {
+			errors = append(errors, newError(CodeMissing, pointer("param", i), "`%s` %s param definition at index `%v` is null", name, element, i))
+			continue
+		}
+		if len(param.Name) == 0 {
+			errors = append(errors, newError(CodeMissing, pointer("param", i, "name"), "`%s` %s param definition at index `%v` missing name definition", name, element, i))
+			continue
+		}
+		if contains(seen, param.Name) {
+			errors = append(errors, newError(CodeDuplicate, pointer("param", i, "name"), "`%s` %s param `%s` is defined more than once", name, element, param.Name))
+		}
+		seen = append(seen, param.Name)
+	}
+	return errors
+}
+
+// findParam returns the Param if found or nil if not found
+func findParam(params []*Param, name string) *Param {
+	for _, p := range params {
+		if p != nil && p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// copyParams returns a deep copy of the Param slice, preserving nil
+func copyParams(params []*Param) []*Param {
+	if params == nil {
+		return nil
+	}
+	copied := make([]*Param, 0, len(params))
+	for _, p := range params {
+		if p == nil {
+			copied = append(copied, nil)
+			continue
+		}
+		param := *p
+		copied = append(copied, &param)
+	}
+	return copied
+}
@@ -0,0 +1,93 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func paramConfiguration() *configuration.Configuration {
+	return &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "base",
+				Path: &configuration.Path{
+					Include: []string{"*"},
+				},
+				Param: []*configuration.Param{
+					{
+						Name:    "format",
+						Default: "json",
+					},
+				},
+			},
+			{
+				Name:    "docs",
+				Extends: "base",
+				Param: []*configuration.Param{
+					{
+						Name:     "out",
+						Required: true,
+					},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"base", "docs"},
+				Param: []*configuration.Param{
+					{
+						Name:     "out",
+						Required: true,
+					},
+					{
+						Name:    "format",
+						Default: "yaml",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestScript_ValidateParams(t *testing.T) {
+	c := paramConfiguration()
+	err := c.Script[0].ValidateParams(c)
+	if err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+	c.Script[0].Param = []*configuration.Param{
+		{
+			Name: "unknown",
+		},
+		{
+			Name: "unknown",
+		},
+		{},
+	}
+	err = c.Script[0].ValidateParams(c)
+	if len(err) != 5 {
+		t.Errorf("Expecting 5 errors, got %v", err)
+	}
+}
+
+func TestScript_Arguments(t *testing.T) {
+	c := paramConfiguration()
+	s := c.FindScript("build")
+	arguments, err := s.Arguments(c, map[string]string{"out": "docs/"})
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if arguments["base"]["format"] != "yaml" || arguments["docs"]["format"] != "yaml" || arguments["docs"]["out"] != "docs/" {
+		t.Errorf("Expecting forwarded arguments, got %v", arguments)
+	}
+	_, err = s.Arguments(c, map[string]string{})
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+	_, err = s.Arguments(c, map[string]string{"out": "docs/", "unknown": "x"})
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
@@ -0,0 +1,321 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// PatchOperation contains a single JSON Patch (RFC 6902) operation
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch applies the JSON Patch (RFC 6902) document of add, remove, replace, move, copy and test operations to Configuration; Configuration is unchanged when any operation fails
+func (c *Configuration) ApplyPatch(ops []byte) error {
+	var operations []*PatchOperation
+	if err := json.Unmarshal(ops, &operations); err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	document, err := c.document()
+	if err != nil {
+		return err
+	}
+	for i, operation := range operations {
+		document, err = applyOperation(document, operation)
+		if err != nil {
+			return fmt.Errorf("patch operation %d: `%s` %s: %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return c.setDocument(document)
+}
+
+// DiffPatch returns the JSON Patch (RFC 6902) document turning Configuration into other
+func (c *Configuration) DiffPatch(other *Configuration) ([]byte, error) {
+	before, err := c.document()
+	if err != nil {
+		return nil, err
+	}
+	after, err := other.document()
+	if err != nil {
+		return nil, err
+	}
+	operations := []*PatchOperation{}
+	if err := diffOperations(before, after, "", &operations); err != nil {
+		return nil, err
+	}
+	return json.Marshal(operations)
+}
+
+// document returns Configuration decoded from its JSON encoding into maps, slices and scalars
+func (c *Configuration) document() (interface{}, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// setDocument replaces Configuration with the decoded JSON document, keeping its changelog
+func (c *Configuration) setDocument(document interface{}) error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	if _, ok := document.(map[string]interface{}); !ok {
+		return fmt.Errorf("patch must result in an object")
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	patched := &Configuration{}
+	if err := json.Unmarshal(data, patched); err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	patched.changelog = c.changelog
+	*c = *patched
+	return nil
+}
+
+// applyOperation returns the document with the operation applied
+func applyOperation(document interface{}, operation *PatchOperation) (interface{}, error) {
+	var value interface{}
+	if operation.Op == "add" || operation.Op == "replace" || operation.Op == "test" {
+		if operation.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return nil, err
+		}
+	}
+	path := tokens(operation.Path)
+	switch operation.Op {
+	case "add":
+		return patchAdd(document, path, value)
+	case "remove":
+		return patchRemove(document, path)
+	case "replace":
+		if len(path) == 0 {
+			return value, nil
+		}
+		document, err := patchRemove(document, path)
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(document, path, value)
+	case "move", "copy":
+		value, err := patchGet(document, tokens(operation.From))
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "move" {
+			document, err = patchRemove(document, tokens(operation.From))
+		} else {
+			value, err = deepCopy(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return patchAdd(document, path, value)
+	case "test":
+		actual, err := patchGet(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(actual, value) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return document, nil
+	}
+	return nil, fmt.Errorf("unknown operation")
+}
+
+// patchGet returns the value of the document at the reference tokens
+func patchGet(document interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := document.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("`%s` does not exist", token)
+			}
+			document = value
+		case []interface{}:
+			i, err := patchIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			document = container[i]
+		default:
+			return nil, fmt.Errorf("`%s` does not exist", token)
+		}
+	}
+	return document, nil
+}
+
+// patchAdd returns the document with the value added at the reference tokens; array elements are inserted and `-` appends
+func patchAdd(document interface{}, path []string, value interface{}) (interface{}, error) {
+	return patchParent(document, path, value, func(container interface{}, token string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			c[token] = value
+			return c, nil
+		case []interface{}:
+			i := len(c)
+			if token != "-" {
+				var err error
+				if i, err = patchIndex(token, len(c)); err != nil {
+					return nil, err
+				}
+			}
+			return append(c[:i], append([]interface{}{value}, c[i:]...)...), nil
+		}
+		return nil, fmt.Errorf("`%s` parent is not an object or array", token)
+	})
+}
+
+// patchRemove returns the document with the value at the reference tokens removed
+func patchRemove(document interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("the document cannot be removed")
+	}
+	return patchParent(document, path, nil, func(container interface{}, token string) (interface{}, error) {
+		switch c := container.(type) {
+		case map[string]interface{}:
+			if _, ok := c[token]; !ok {
+				return nil, fmt.Errorf("`%s` does not exist", token)
+			}
+			delete(c, token)
+			return c, nil
+		case []interface{}:
+			i, err := patchIndex(token, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(c[:i], c[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("`%s` does not exist", token)
+	})
+}
+
+// patchParent returns the document with edit applied to the container of the last reference token; an empty path replaces the document with the value
+func patchParent(document interface{}, path []string, value interface{}, edit func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	if len(path) == 1 {
+		return edit(document, path[0])
+	}
+	child, err := patchGet(document, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := patchParent(child, path[1:], value, edit)
+	if err != nil {
+		return nil, err
+	}
+	switch c := document.(type) {
+	case map[string]interface{}:
+		c[path[0]] = updated
+	case []interface{}:
+		i, _ := patchIndex(path[0], len(c)-1)
+		c[i] = updated
+	}
+	return document, nil
+}
+
+// patchIndex returns the array index of the reference token, at most max
+func patchIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || strconv.Itoa(i) != token {
+		return 0, fmt.Errorf("`%s` is not a valid index", token)
+	}
+	return i, nil
+}
+
+// deepCopy returns a copy of the decoded JSON value sharing no maps or slices
+func deepCopy(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
+
+// diffOperations appends the operations turning before into after at the JSON pointer; objects and arrays are compared member by member, trailing array elements are removed or appended
+func diffOperations(before, after interface{}, p string, operations *[]*PatchOperation) error {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(b)+len(a))
+			for key := range b {
+				keys = append(keys, key)
+			}
+			for key := range a {
+				if _, ok := b[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				child := p + pointer(key)
+				value, inAfter := a[key]
+				if _, inBefore := b[key]; !inBefore {
+					if err := appendOperation(operations, "add", child, value); err != nil {
+						return err
+					}
+				} else if !inAfter {
+					*operations = append(*operations, &PatchOperation{Op: "remove", Path: child})
+				} else if err := diffOperations(b[key], value, child, operations); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			for i := 0; i < len(b) && i < len(a); i++ {
+				if err := diffOperations(b[i], a[i], p+pointer(i), operations); err != nil {
+					return err
+				}
+			}
+			for i := len(b) - 1; i >= len(a); i-- {
+				*operations = append(*operations, &PatchOperation{Op: "remove", Path: p + pointer(i)})
+			}
+			for i := len(b); i < len(a); i++ {
+				if err := appendOperation(operations, "add", p+"/-", a[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return appendOperation(operations, "replace", p, after)
+}
+
+// appendOperation appends the operation with the value encoded
+func appendOperation(operations *[]*PatchOperation, op string, p string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*operations = append(*operations, &PatchOperation{Op: op, Path: p, Value: data})
+	return nil
+}
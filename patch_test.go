@@ -0,0 +1,79 @@
+package configuration_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_ApplyPatch(t *testing.T) {
+	c := &configuration.Configuration{
+		Name:    "lorem",
+		Version: "1.0.0",
+		Task: []*configuration.Task{
+			{Name: "docs", Path: &configuration.Path{Include: []string{"*.go"}}},
+			{Name: "lint"},
+		},
+	}
+	err := c.ApplyPatch([]byte(`[
+		{"op": "test", "path": "/name", "value": "lorem"},
+		{"op": "replace", "path": "/version", "value": "1.1.0"},
+		{"op": "add", "path": "/task/0/path/include/-", "value": "*.md"},
+		{"op": "add", "path": "/task/1", "value": {"name": "test"}},
+		{"op": "copy", "from": "/task/0/path", "path": "/task/1/path"},
+		{"op": "move", "from": "/name", "path": "/description"},
+		{"op": "remove", "path": "/task/2"}
+	]`))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Version != "1.1.0" || len(c.Name) > 0 || c.Description != "lorem" {
+		t.Errorf("Expecting patched scalars, got %+v", c)
+	}
+	if len(c.Task) != 2 || c.Task[1].Name != "test" || !reflect.DeepEqual(c.Task[1].Path.Include, []string{"*.go", "*.md"}) {
+		t.Errorf("Expecting patched tasks, got %+v", c.Task)
+	}
+	for _, ops := range []string{
+		`[{"op": "test", "path": "/version", "value": "2.0.0"}]`,
+		`[{"op": "remove", "path": "/task/5"}]`,
+		`[{"op": "replace", "path": "/author", "value": "ipsum"}]`,
+		`[{"op": "add", "path": "/task/0/name", "value": 1}]`,
+		`[{"op": "merge", "path": "/name"}]`,
+		`{}`,
+	} {
+		if err := c.ApplyPatch([]byte(ops)); err == nil {
+			t.Errorf("Expecting error for %s, got nil", ops)
+		}
+	}
+	if c.Version != "1.1.0" {
+		t.Errorf("Expecting unchanged Configuration, got %v", c.Version)
+	}
+}
+
+func TestConfiguration_DiffPatch(t *testing.T) {
+	before := &configuration.Configuration{
+		Name:    "lorem",
+		Version: "1.0.0",
+		Task:    []*configuration.Task{{Name: "docs"}, {Name: "lint"}, {Name: "test"}},
+	}
+	after := &configuration.Configuration{
+		Version: "1.1.0",
+		License: "MIT",
+		Task:    []*configuration.Task{{Name: "docs", Notes: "ipsum"}},
+	}
+	patch, err := before.DiffPatch(after)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	expected := `[{"op":"add","path":"/license","value":"MIT"},{"op":"remove","path":"/name"},{"op":"add","path":"/task/0/notes","value":"ipsum"},{"op":"remove","path":"/task/2"},{"op":"remove","path":"/task/1"},{"op":"replace","path":"/version","value":"1.1.0"}]`
+	if string(patch) != expected {
+		t.Errorf("Expecting %v, got %v", expected, string(patch))
+	}
+	if err := before.ApplyPatch(patch); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("Expecting %+v, got %+v", after, before)
+	}
+}
@@ -0,0 +1,38 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// pinPrefix constant for the fragment pinning the sha256 content hash of a linked file, such as `base.json#sha256=<hex>`
+	pinPrefix = "#sha256="
+)
+
+// ErrPinMismatch is the error class of every linked file whose content differs from its pinned hash
+var ErrPinMismatch = errors.New("content does not match pinned hash")
+
+// splitPin returns the link without its pinned hash, and the lowercase hex sha256 hash it pins, if any
+func splitPin(link string) (string, string) {
+	i := strings.LastIndex(link, pinPrefix)
+	if i < 0 {
+		return link, ""
+	}
+	return link[:i], strings.ToLower(link[i+len(pinPrefix):])
+}
+
+// verifyPin returns an error wrapping ErrPinMismatch if the sha256 hash of the data differs from sum; an empty sum pins nothing
+func verifyPin(location string, data []byte, sum string) error {
+	if len(sum) == 0 {
+		return nil
+	}
+	actual := sha256.Sum256(data)
+	if hex.EncodeToString(actual[:]) != sum {
+		return fmt.Errorf("`%s` sha256 `%s`: %w `%s`", location, hex.EncodeToString(actual[:]), ErrPinMismatch, sum)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadPinnedExtends(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	base := []byte(`{"name": "base"}`)
+	sum := sha256.Sum256(base)
+	pin := "#sha256=" + hex.EncodeToString(sum[:])
+	served := []byte(`{"name": "stale"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(served)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "base.json"), base, 0644)
+	for _, extends := range []string{"base.json" + pin, server.URL + "/base.json" + pin} {
+		os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"extends": "`+extends+`"}`), 0644)
+		c := &configuration.Configuration{}
+		err := c.LoadFrom(dir)
+		if extends == server.URL+"/base.json"+pin {
+			if !errors.Is(err, configuration.ErrPinMismatch) {
+				t.Errorf("Expecting ErrPinMismatch, got %v", err)
+			}
+			served = base
+			c = &configuration.Configuration{}
+			err = c.LoadFrom(dir)
+		}
+		if err != nil || c.Name != "base" {
+			t.Errorf("Expecting pinned base, got %v %v", err, c.Name)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "base.json"), []byte(`{"name": "changed"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "emits.json"), []byte(`{"extends": "base.json`+pin+`"}`), 0644)
+	if err := (&configuration.Configuration{}).LoadFrom(dir); !errors.Is(err, configuration.ErrPinMismatch) {
+		t.Errorf("Expecting ErrPinMismatch, got %v", err)
+	}
+}
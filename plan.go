@@ -0,0 +1,197 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/emits-io/core"
+)
+
+const (
+	// PlanSchema constant for the serialized Plan schema version; incremented on any incompatible change
+	PlanSchema = 1
+)
+
+// Plan contains the ordered steps a Script resolves to; nothing is executed while planning
+type Plan struct {
+	Schema int     `json:"schema"`
+	Script string  `json:"script"`
+	Root   string  `json:"root"`
+	Step   []*Step `json:"step"`
+}
+
+// Step contains a single Task of Plan with its resolved files
+type Step struct {
+	Task    string      `json:"task"`
+	Include []string    `json:"include,omitempty"`
+	Exclude []string    `json:"exclude,omitempty"`
+	File    []*PlanFile `json:"file"`
+}
+
+// PlanFile contains a single resolved file of Step and the File definition steps applied to it
+type PlanFile struct {
+	Path   string                    `json:"path"`
+	Type   string                    `json:"type"`
+	Output string                    `json:"output,omitempty"`
+	Source bool                      `json:"source,omitempty"`
+	Plugin []string                  `json:"plugin,omitempty"`
+	Regex  []*core.RegularExpression `json:"regex,omitempty"`
+}
+
+// Plan resolves the named Script against the current directory into an ordered Plan of tasks, files and modify steps
+func (c *Configuration) Plan(script string) (*Plan, error) {
+	return c.PlanRoot(script, ".")
+}
+
+// PlanContext is Plan bounded by ctx: the directory scan stops with its error once ctx is done
+func (c *Configuration) PlanContext(ctx context.Context, script string) (*Plan, error) {
+	return c.plan(ctx, script, ".", os.DirFS("."))
+}
+
+// PlanRoot resolves the named Script against root into an ordered Plan of tasks, files and modify steps
+func (c *Configuration) PlanRoot(script string, root string) (*Plan, error) {
+	return c.plan(context.Background(), script, root, os.DirFS(root))
+}
+
+// PlanFS resolves the named Script against the file system into an ordered Plan of tasks, files and modify steps
+func (c *Configuration) PlanFS(script string, fsys fs.FS) (*Plan, error) {
+	return c.plan(context.Background(), script, ".", fsys)
+}
+
+// plan resolves the named Script against the file system, walked until ctx is done, recording root as the Plan root; steps follow TaskOrder so producers precede their consumers, a Dedupe Script plans every file in the first step matching it only, disabled tasks, files and modify steps are skipped
+func (c *Configuration) plan(ctx context.Context, script string, root string, fsys fs.FS) (*Plan, error) {
+	s, err := c.enabledScript(script)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{
+		Schema: PlanSchema,
+		Script: s.Name,
+		Root:   root,
+	}
+	var enabled []string
+	for _, name := range s.Task {
+		t := c.FindTask(name)
+		if t == nil {
+			return nil, fmt.Errorf("`%s` script referencing unknown `%s` task definition", s.Name, name)
+		}
+		if !t.Disabled {
+			enabled = append(enabled, name)
+		}
+	}
+	order, err := c.planOrder(enabled)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, name := range order {
+		task, err := c.flatten(c.FindTask(name), nil)
+		if err != nil {
+			return nil, err
+		}
+		step := &Step{
+			Task: task.Name,
+			File: []*PlanFile{},
+		}
+		if task.Path != nil {
+			step.Include = task.Path.Include
+			step.Exclude = task.Path.Exclude
+		}
+		files, err := resolveFilesFS(ctx, fsys, task.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			extension := strings.TrimPrefix(path.Ext(file), ".")
+			f := c.FindFile(extension)
+			if f == nil || f.Parse.Excludes(file) || s.Dedupe && seen[file] {
+				continue
+			}
+			seen[file] = true
+			planFile := &PlanFile{
+				Path: file,
+				Type: extension,
+			}
+			if len(f.Output) > 0 {
+				planFile.Output, err = f.OutputPath(file)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if f.Parse != nil {
+				planFile.Source = f.Parse.Source
+			}
+			for _, plugin := range f.Modify.enabledPlugins() {
+				planFile.Plugin = append(planFile.Plugin, plugin.Path)
+			}
+			for _, regex := range f.Modify.enabledRegex() {
+				planFile.Regex = append(planFile.Regex, &core.RegularExpression{
+					Find:    regex.Find,
+					Replace: regex.Replace,
+				})
+			}
+			step.File = append(step.File, planFile)
+		}
+		plan.Step = append(plan.Step, step)
+	}
+	return plan, nil
+}
+
+// Write generates and saves the Plan to disk for use by external schedulers
+func (p *Plan) Write(path string) error {
+	data, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodePlan returns the Plan serialized by Write; returns an error if the schema version is not supported
+func DecodePlan(data []byte) (*Plan, error) {
+	plan := &Plan{}
+	err := json.Unmarshal(data, plan)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Schema != PlanSchema {
+		return nil, fmt.Errorf("unsupported plan schema `%v`, expecting `%v`", plan.Schema, PlanSchema)
+	}
+	return plan, nil
+}
+
+// planOrder returns the task names with those in the dependency graph reordered by TaskOrder within the slots they occupy; project tasks keep their declared position
+func (c *Configuration) planOrder(names []string) ([]string, error) {
+	dependencies := c.TaskDependencies()
+	var known []string
+	for _, name := range names {
+		if _, ok := dependencies[name]; ok {
+			known = append(known, name)
+		}
+	}
+	if len(known) == 0 {
+		return names, nil
+	}
+	ordered, err := c.TaskOrder(known...)
+	if err != nil {
+		return nil, err
+	}
+	var order []string
+	for _, name := range names {
+		if _, ok := dependencies[name]; !ok {
+			order = append(order, name)
+		} else if len(ordered) > 0 {
+			order = append(order, ordered[0])
+			ordered = ordered[1:]
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,148 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func planConfiguration(root string) *configuration.Configuration {
+	for _, name := range []string{"main.go", "cmd/run.go", "vendor/lib/lib.go", "gen/api.pb.go", "web/app.js", "readme.md"} {
+		os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(root, name), []byte(""), 0644)
+	}
+	return &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "go",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+					Exclude: []string{"vendor/**"},
+				},
+			},
+			{
+				Name: "all",
+				Path: &configuration.Path{
+					Include: []string{"**/*"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"go", "all"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+					Exclude: []string{"*.pb.go"},
+				},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{
+							Path: "./foo.js",
+						},
+					},
+				},
+			},
+			{
+				Type: []string{"js"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConfiguration_PlanRoot(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	plan, err := c.PlanRoot("build", root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(plan.Step) != 2 || plan.Step[0].Task != "go" || plan.Step[1].Task != "all" {
+		t.Fatalf("Expecting go and all steps, got %v", plan.Step)
+	}
+	if len(plan.Step[0].File) != 2 || plan.Step[0].File[0].Path != "cmd/run.go" || plan.Step[0].File[1].Path != "main.go" {
+		t.Errorf("Expecting cmd/run.go and main.go, got %v", plan.Step[0].File)
+	}
+	if plan.Step[0].File[0].Plugin[0] != "./foo.js" {
+		t.Errorf("Expecting plugin modify step, got %v", plan.Step[0].File[0].Plugin)
+	}
+	if len(plan.Step[1].File) != 4 {
+		t.Errorf("Expecting 4 files, got %v", plan.Step[1].File)
+	}
+	_, err = c.PlanRoot("unknown", root)
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestConfiguration_PlanOrder(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	c.Task[0].Inputs = []string{"web/*.js"}
+	c.Task[1].Outputs = []string{"web/app.js"}
+	plan, err := c.PlanRoot("build", root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(plan.Step) != 2 || plan.Step[0].Task != "all" || plan.Step[1].Task != "go" {
+		t.Errorf("Expecting all step before go step, got %v", plan.Step)
+	}
+	c.Task[1].Inputs = []string{"main.go"}
+	c.Task[0].Outputs = []string{"*.go"}
+	_, err = c.PlanRoot("build", root)
+	if err == nil {
+		t.Errorf("Expecting cycle error, got nil")
+	}
+}
+
+func TestPlan_Write(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	c.File[0].Modify.Regex = []*core.RegularExpression{
+		{
+			Find:    "foo",
+			Replace: "bar",
+		},
+	}
+	plan, err := c.PlanRoot("build", root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "plan.json")
+	err = plan.Write(out)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	decoded, err := configuration.DecodePlan(data)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(plan, decoded) {
+		t.Errorf("Expecting %v, got %v", plan, decoded)
+	}
+	if !strings.Contains(string(data), `"replace": "bar"`) {
+		t.Errorf("Expecting serialized regex modify step, got %s", data)
+	}
+	_, err = configuration.DecodePlan([]byte(`{"schema":99}`))
+	if err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
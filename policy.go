@@ -0,0 +1,102 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy contains organization standards every Configuration is checked against by CheckPolicy
+type Policy struct {
+	RequiredTasks    []string `json:"requiredTasks,omitempty"`
+	ForbiddenPlugins []string `json:"forbiddenPlugins,omitempty"`
+	RequiredExcludes []string `json:"requiredExcludes,omitempty"`
+}
+
+// CheckPolicy returns a Report with an error for every required task not defined, every enabled plugin whose path matches a forbidden pattern and every task whose include patterns may match a required exclude it does not exclude
+func (c *Configuration) CheckPolicy(policy *Policy) *Report {
+	if c == nil {
+		return &Report{Errors: []error{nullConfiguration()}}
+	}
+	report := &Report{}
+	if policy == nil {
+		return report
+	}
+	for _, name := range policy.RequiredTasks {
+		if c.FindTask(name) == nil {
+			report.Errors = append(report.Errors, newError(CodePolicy, pointer("task"), "policy requires `%s` task definition", name))
+		}
+	}
+	for i, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file := c.resolveFile(f)
+		if file.Disabled || file.Modify == nil {
+			continue
+		}
+		for j, plugin := range file.Modify.Plugin {
+			if plugin == nil {
+				continue
+			}
+			if plugin.Disabled || file.Modify.Disabled {
+				continue
+			}
+			for _, forbidden := range policy.ForbiddenPlugins {
+				if plugin.Path == forbidden || match(forbidden, plugin.Path) {
+					report.Errors = append(report.Errors, newError(CodePolicy, pointer("file", i, "modify", "plugin", j, "path"), "policy forbids `%s` plugin", plugin.Path))
+					break
+				}
+			}
+		}
+	}
+	for i, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		task := c.resolve(t)
+		if task.Disabled || task.Path == nil {
+			continue
+		}
+		for _, required := range policy.RequiredExcludes {
+			if !violates(task.Path, required) {
+				continue
+			}
+			e := newError(CodePolicy, pointer("task", i, "path", "exclude"), "`%s` task must exclude `%s` by policy", task.Name, required)
+			title := fmt.Sprintf("Exclude `%s`", required)
+			excludes := append(append([]string{}, task.Path.Exclude...), required)
+			if t.Path == nil {
+				e.add(title, pointer("task", i, "path"), map[string][]string{"exclude": excludes})
+			} else if t.Path.Exclude == nil {
+				e.add(title, pointer("task", i, "path", "exclude"), excludes)
+			} else {
+				e.add(title, pointer("task", i, "path", "exclude", "-"), required)
+			}
+			report.Errors = append(report.Errors, e)
+		}
+	}
+	return report
+}
+
+// violates returns true if the include patterns of the Path may match a path the required exclude matches and its exclude patterns do not cover it; a required exclude without glob meta characters, such as `node_modules`, also names the directory and every path below it, as an exclude of a directory skips it whole
+func violates(p *Path, required string) bool {
+	patterns := []string{required}
+	if !hasMeta(required) && !strings.ContainsAny(required, "{}") {
+		patterns = append(patterns, strings.TrimSuffix(required, "/")+"/**")
+	}
+	for _, pattern := range patterns {
+		if intersectsAny(positive(p.Include), pattern) && !coveredByAny(p.Exclude, required) && !coveredByAny(p.Exclude, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectsAny returns true if any pattern may match a path the pattern b matches
+func intersectsAny(patterns []string, b string) bool {
+	for _, pattern := range patterns {
+		if intersects(pattern, b) {
+			return true
+		}
+	}
+	return false
+}
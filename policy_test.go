@@ -0,0 +1,84 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_CheckPolicy(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"**/*.js"},
+				},
+			},
+			{
+				Name: "ipsum",
+				Path: &configuration.Path{
+					Include: []string{"**/*.js"},
+					Exclude: []string{"node_modules/**"},
+				},
+			},
+			{
+				Name: "dolor",
+				Path: &configuration.Path{
+					Include: []string{"src/*.js"},
+				},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{
+							Path: "plugins/unsafe.js",
+						},
+						{
+							Path: "plugins/safe.js",
+						},
+					},
+				},
+			},
+		},
+	}
+	report := c.CheckPolicy(&configuration.Policy{
+		RequiredTasks:    []string{"lorem", "lint"},
+		ForbiddenPlugins: []string{"plugins/unsafe*"},
+		RequiredExcludes: []string{"node_modules/**"},
+	})
+	expected := []string{"/task", "/file/0/modify/plugin/0/path", "/task/0/path/exclude"}
+	if len(report.Errors) != len(expected) {
+		t.Fatalf("Expecting %v errors, got %v", len(expected), report.Errors)
+	}
+	for i := range expected {
+		if configuration.Pointer(report.Errors[i]) != expected[i] || configuration.ErrorCode(report.Errors[i]) != configuration.CodePolicy {
+			t.Errorf("Expecting %v, got %v", expected[i], report.Errors[i])
+		}
+	}
+	fixes := report.Fixes()
+	if len(fixes) != 1 || fixes[0].Op != "add" || fixes[0].Path != "/task/0/path/exclude" {
+		t.Errorf("Expecting exclude fix, got %v", fixes)
+	}
+	if report := c.CheckPolicy(nil); !report.Valid() {
+		t.Errorf("Expecting valid report, got %v", report.Errors)
+	}
+}
+
+func TestConfiguration_CheckPolicy_Directory(t *testing.T) {
+	c := &configuration.Configuration{
+		Task: []*configuration.Task{
+			{Name: "lorem", Path: &configuration.Path{Include: []string{"**/*.js"}}},
+			{Name: "ipsum", Path: &configuration.Path{Include: []string{"**/*.js"}, Exclude: []string{"node_modules"}}},
+			{Name: "dolor", Path: &configuration.Path{Include: []string{"**/*.js"}, Exclude: []string{"node_modules/**"}}},
+			{Name: "sit", Path: &configuration.Path{Include: []string{"src/*.js"}}},
+		},
+	}
+	report := c.CheckPolicy(&configuration.Policy{RequiredExcludes: []string{"node_modules"}})
+	if len(report.Errors) != 1 || configuration.Pointer(report.Errors[0]) != "/task/0/path/exclude" {
+		t.Errorf("Expecting only the lorem task to violate, got %v", report.Errors)
+	}
+}
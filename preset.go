@@ -0,0 +1,89 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// presets contains every registered preset File keyed by name
+var presets = struct {
+	sync.RWMutex
+	byName map[string]*File
+}{
+	byName: map[string]*File{},
+}
+
+func init() {
+	for _, l := range languages {
+		RegisterPreset(l.name, l.file())
+	}
+}
+
+// RegisterPreset adds a copy of the File to the preset registry under the name, replacing any preset of the same name; external packages call it from init to add presets for Init and ApplyPreset
+func RegisterPreset(name string, file *File) {
+	presets.Lock()
+	defer presets.Unlock()
+	presets.byName[strings.ToLower(strings.TrimSpace(name))] = file.Clone()
+}
+
+// Presets returns the sorted name of every registered preset
+func Presets() []string {
+	presets.RLock()
+	defer presets.RUnlock()
+	var names []string
+	for name := range presets.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns a copy of the named preset, such as `python`; a file type such as `py` names the first preset in order of name defining it; nil if unknown
+func Preset(name string) *File {
+	name = strings.ToLower(strings.TrimSpace(name))
+	presets.RLock()
+	defer presets.RUnlock()
+	if preset, ok := presets.byName[name]; ok {
+		return preset.Clone()
+	}
+	var match string
+	for key, preset := range presets.byName {
+		if preset != nil && contains(preset.Type, name) && (len(match) == 0 || key < match) {
+			match = key
+		}
+	}
+	if len(match) > 0 {
+		return presets.byName[match].Clone()
+	}
+	return nil
+}
+
+// file returns a new File of the language with its file types and comment syntax
+func (l *language) file() *File {
+	return &File{
+		Type: copyStrings(l.extension),
+		Parse: &Parse{
+			Comment: copyComment(l.comment),
+		},
+	}
+}
+
+// ApplyPreset sets the parse comment of File to the comment syntax of the named preset, and its types to those of the preset when File has none; returns an error if the preset is unknown
+func (f *File) ApplyPreset(name string) error {
+	preset := Preset(name)
+	if preset == nil {
+		return fmt.Errorf("unknown `%s` preset, expecting one of `%s`", name, strings.Join(Presets(), "`, `"))
+	}
+	if len(f.Type) == 0 {
+		f.Type = preset.Type
+	}
+	if f.Parse == nil {
+		f.Parse = &Parse{}
+	}
+	if preset.Parse != nil {
+		f.Parse.Comment = preset.Parse.Comment
+	}
+	return nil
+}
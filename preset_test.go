@@ -0,0 +1,61 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestFile_ApplyPreset(t *testing.T) {
+	f := &configuration.File{}
+	if err := f.ApplyPreset("python"); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(f.Type) != 1 || f.Type[0] != "py" || f.Parse.Comment.Line != "#" || f.Parse.Comment.Block.Start != `"""` {
+		t.Errorf("Expecting python preset, got %v %v", f.Type, f.Parse.Comment)
+	}
+	if errs := f.Validate(); errs != nil {
+		t.Errorf("Expecting valid file, got %v", errs)
+	}
+	f = &configuration.File{Type: []string{"h"}, Parse: &configuration.Parse{Source: true}}
+	if err := f.ApplyPreset("cpp"); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(f.Type) != 1 || !f.Parse.Source || f.Parse.Comment.Line != "//" {
+		t.Errorf("Expecting c preset keeping types and source, got %v %v", f.Type, f.Parse)
+	}
+	if err := f.ApplyPreset("cobol"); err == nil {
+		t.Errorf("Expecting unknown preset error, got nil")
+	}
+	p := configuration.Preset("Go")
+	p.Parse.Comment.Line = "#"
+	if configuration.Preset("go").Parse.Comment.Line != "//" {
+		t.Errorf("Expecting presets to be copies")
+	}
+	if names := configuration.Presets(); len(names) < 11 || names[0] != "c" {
+		t.Errorf("Expecting sorted presets, got %v", names)
+	}
+}
+
+func TestRegisterPreset(t *testing.T) {
+	file := &configuration.File{
+		Type: []string{"tf"},
+		Parse: &configuration.Parse{
+			Comment: &core.Comment{Line: "#"},
+		},
+	}
+	configuration.RegisterPreset("Terraform", file)
+	file.Type[0] = "hcl"
+	f := &configuration.File{}
+	if err := f.ApplyPreset("terraform"); err != nil || f.Type[0] != "tf" || f.Parse.Comment.Line != "#" {
+		t.Errorf("Expecting terraform preset, got %v %v", err, f)
+	}
+	if p := configuration.Preset("tf"); p == nil || p.Parse.Comment.Line != "#" {
+		t.Errorf("Expecting terraform preset by file type, got %v", p)
+	}
+	c := configuration.Init(configuration.InitOptions{Root: t.TempDir(), Languages: []string{"terraform"}})
+	if len(c.File) != 1 || c.File[0].Type[0] != "tf" {
+		t.Errorf("Expecting terraform file, got %v", c.File)
+	}
+}
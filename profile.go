@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Profile contains the tasks, scripts and files a named profile overrides on Configuration
+type Profile struct {
+	Task   []*Task   `json:"task,omitempty"`
+	Script []*Script `json:"script,omitempty"`
+	File   []*File   `json:"file,omitempty"`
+}
+
+// Profiles returns the name of every Profile on Configuration, sorted
+func (c *Configuration) Profiles() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Profile))
+	for name := range c.Profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns a copy of Configuration with the named Profile applied by Overlay; returns an error if the profile does not exist
+func (c *Configuration) WithProfile(name string) (*Configuration, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	profile, ok := c.Profile[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("unknown `%s` profile", name)
+	}
+	view := c.Clone()
+	view.Overlay(&Configuration{
+		Task:   cloneTasks(profile.Task),
+		Script: cloneScripts(profile.Script),
+		File:   cloneFiles(profile.File),
+	})
+	return view, nil
+}
@@ -0,0 +1,49 @@
+package configuration_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_WithProfile(t *testing.T) {
+	c := &configuration.Configuration{}
+	err := c.LoadReader(strings.NewReader(`{
+		"task": [{"name": "docs", "path": {"include": ["src/**/*.go"]}}],
+		"file": [{"type": ["go"], "output": "{{path}}.md"}],
+		"profile": {
+			"ci": {
+				"task": [{"name": "docs", "path": {"include": ["**/*.go"]}}],
+				"script": [{"name": "check", "task": ["docs"]}],
+				"file": [{"type": ["go"], "audit": [{"path": "audit.js"}]}]
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(c.Profiles(), []string{"ci"}) {
+		t.Errorf("Expecting [ci], got %v", c.Profiles())
+	}
+	view, err := c.WithProfile("ci")
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if include := view.Task[0].Path.Include; !reflect.DeepEqual(include, []string{"**/*.go"}) {
+		t.Errorf("Expecting [**/*.go], got %v", include)
+	}
+	if view.FindScript("check") == nil {
+		t.Errorf("Expecting check script, got nil")
+	}
+	if f := view.File[0]; f.Output != "{{path}}.md" || len(f.Audit) != 1 {
+		t.Errorf("Expecting overlaid file, got %+v", f)
+	}
+	if include := c.Task[0].Path.Include; !reflect.DeepEqual(include, []string{"src/**/*.go"}) {
+		t.Errorf("Expecting unchanged Configuration, got %v", include)
+	}
+	if _, err := c.WithProfile("cd"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
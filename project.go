@@ -0,0 +1,81 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProjectNames returns the name of every project defined on Configuration, sorted
+func (c *Configuration) ProjectNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Projects))
+	for name, project := range c.Projects {
+		if project != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Project returns the named project with the vars of Configuration it does not override, and its files and definitions when the project has none; its scripts may reference the tasks of sibling projects as `project:task`; returns an error if the project does not exist
+func (c *Configuration) Project(name string) (*Configuration, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	if c.Projects[name] == nil {
+		return nil, fmt.Errorf("unknown `%s` project", name)
+	}
+	return c.scoped(name), nil
+}
+
+// ValidateProjects returns the errors of every project validated with Project, prefixed with its pointer
+func (c *Configuration) ValidateProjects() []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	var errors []error
+	for _, name := range c.ProjectNames() {
+		errors = append(errors, prefix(pointer("projects", name), c.scoped(name).Validate())...)
+	}
+	return errors
+}
+
+// scoped returns a shallow copy of the named project with the vars, files and definitions of Configuration it does not override and Configuration as its workspace
+func (c *Configuration) scoped(name string) *Configuration {
+	project := *c.Projects[name]
+	project.workspace = c
+	if len(project.File) == 0 {
+		project.File = c.File
+	}
+	if project.Definitions == nil {
+		project.Definitions = c.Definitions
+	}
+	if len(c.Var) > 0 {
+		project.Var = map[string]string{}
+		for key, value := range c.Var {
+			project.Var[key] = value
+		}
+		for key, value := range c.Projects[name].Var {
+			project.Var[key] = value
+		}
+	}
+	return &project
+}
+
+// projectTask returns the Task referenced as `project:task` within the projects of Configuration or of its workspace, or nil
+func (c *Configuration) projectTask(reference string) *Task {
+	name, task, ok := strings.Cut(reference, ":")
+	if !ok {
+		return nil
+	}
+	for workspace := c; workspace != nil; workspace = workspace.workspace {
+		if project := workspace.Projects[name]; project != nil {
+			return project.FindTask(task)
+		}
+	}
+	return nil
+}
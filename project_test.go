@@ -0,0 +1,60 @@
+package configuration_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Project(t *testing.T) {
+	c := &configuration.Configuration{}
+	err := c.LoadReader(strings.NewReader(`{
+		"var": {"src": "src"},
+		"file": [{"type": ["go", "ts"], "parse": {"comment": {"line": "//"}}}],
+		"projects": {
+			"api": {
+				"var": {"src": "api"},
+				"task": [{"name": "docs", "path": {"include": ["{{var.src}}/**/*.go"]}}]
+			},
+			"web": {
+				"task": [{"name": "docs", "path": {"include": ["{{var.src}}/**/*.ts"]}}],
+				"script": [{"name": "all", "task": ["docs", "api:docs"]}]
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(c.ProjectNames(), []string{"api", "web"}) {
+		t.Errorf("Expecting [api web], got %v", c.ProjectNames())
+	}
+	web, err := c.Project("web")
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(web.File) != 1 {
+		t.Errorf("Expecting workspace files, got %v", web.File)
+	}
+	if include := web.Task[0].Path.Include[0]; include != "src/**/*.ts" {
+		t.Errorf("Expecting src/**/*.ts, got %v", include)
+	}
+	if include := c.Projects["api"].Task[0].Path.Include[0]; include != "api/**/*.go" {
+		t.Errorf("Expecting api/**/*.go, got %v", include)
+	}
+	if task := web.FindTask("api:docs"); task == nil || task != c.Projects["api"].Task[0] {
+		t.Errorf("Expecting api docs task, got %v", task)
+	}
+	if errors := c.ValidateProjects(); len(errors) != 0 {
+		t.Errorf("Expecting no errors, got %v", errors)
+	}
+	c.Projects["web"].Script[0].Task[1] = "cli:docs"
+	errors := c.ValidateProjects()
+	if len(errors) != 1 || errors[0].(*configuration.ValidationError).Pointer != "/projects/web/script/0/task/1" {
+		t.Errorf("Expecting unknown cli:docs error, got %v", errors)
+	}
+	if _, err := c.Project("cli"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
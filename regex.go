@@ -0,0 +1,108 @@
+package configuration
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/emits-io/core"
+)
+
+// Reference contains a single `$name`, `${name}`, `$1` or `${1}` group reference found in a Replace template
+type Reference struct {
+	Name   string
+	Index  int
+	Braced bool
+}
+
+// References returns all group references within a Replace template; `$$` is an escaped `$` and never a reference
+func References(template string) (references []*Reference, malformed []int) {
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' {
+			continue
+		}
+		if i+1 < len(template) && template[i+1] == '$' {
+			i++
+			continue
+		}
+		name, braced, length := extractReference(template[i+1:])
+		if length == 0 {
+			malformed = append(malformed, i)
+			continue
+		}
+		reference := &Reference{
+			Name:   name,
+			Index:  -1,
+			Braced: braced,
+		}
+		if index, err := strconv.Atoi(name); err == nil {
+			reference.Index = index
+		}
+		references = append(references, reference)
+		i += length
+	}
+	return references, malformed
+}
+
+// extractReference mirrors regexp.Expand name extraction; returns zero length when malformed
+func extractReference(template string) (name string, braced bool, length int) {
+	if len(template) == 0 {
+		return "", false, 0
+	}
+	if template[0] == '{' {
+		braced = true
+		template = template[1:]
+	}
+	i := 0
+	for i < len(template) {
+		r := template[i]
+		if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			break
+		}
+		i++
+	}
+	if i == 0 {
+		return "", braced, 0
+	}
+	name = template[:i]
+	if braced {
+		if i >= len(template) || template[i] != '}' {
+			return "", braced, 0
+		}
+		return name, braced, i + 2
+	}
+	return name, braced, i
+}
+
+// validateRegex returns all known errors of a RegularExpression on File; used by File.Validate
+func validateRegex(f *File, i int, regex *core.RegularExpression) []error {
+	var errors []error
+	fileType := strings.Join(f.Type, ",")
+	if len(regex.Find) == 0 {
+		return append(errors, newError(CodeEmpty, pointer("modify", "regex", i, "find"), "`%s` file modify find definition at index `%v` is empty", fileType, i))
+	}
+	compiled, err := regexp.Compile(regex.Find)
+	if err != nil {
+		return append(errors, newError(CodeInvalid, pointer("modify", "regex", i, "find"), "`%s` file modify find definition at index `%v` is invalid: %v", fileType, i, err))
+	}
+	references, malformed := References(regex.Replace)
+	for _, position := range malformed {
+		errors = append(errors, newError(CodeInvalid, pointer("modify", "regex", i, "replace"), "`%s` file modify replace definition at index `%v` has malformed `$` at position `%v`; use `$$` for a literal `$`", fileType, i, position))
+	}
+	for _, reference := range references {
+		if reference.Index >= 0 {
+			if reference.Index > compiled.NumSubexp() {
+				errors = append(errors, newError(CodeUnknown, pointer("modify", "regex", i, "replace"), "`%s` file modify replace definition at index `%v` references unknown group `%v`", fileType, i, reference.Index))
+			}
+			continue
+		}
+		if compiled.SubexpIndex(reference.Name) < 0 {
+			if reference.Braced {
+				errors = append(errors, newError(CodeUnknown, pointer("modify", "regex", i, "replace"), "`%s` file modify replace definition at index `%v` references unknown group `%s`", fileType, i, reference.Name))
+			} else {
+				errors = append(errors, newError(CodeUnknown, pointer("modify", "regex", i, "replace"), "`%s` file modify replace definition at index `%v` references unknown group `%s`; delimit references followed by text with `${}`", fileType, i, reference.Name))
+			}
+		}
+	}
+	return errors
+}
@@ -0,0 +1,67 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestReferences(t *testing.T) {
+	references, malformed := configuration.References("${year}-$1 $$ ${2}x $name")
+	if len(malformed) != 0 {
+		t.Errorf("Expecting 0 malformed, got %v", malformed)
+	}
+	if len(references) != 4 {
+		t.Fatalf("Expecting 4 references, got %v", len(references))
+	}
+	if references[0].Name != "year" || !references[0].Braced || references[0].Index != -1 {
+		t.Errorf("Expecting braced year reference, got %v", references[0])
+	}
+	if references[1].Index != 1 {
+		t.Errorf("Expecting index 1, got %v", references[1].Index)
+	}
+	if references[2].Index != 2 || !references[2].Braced {
+		t.Errorf("Expecting braced index 2, got %v", references[2])
+	}
+	if references[3].Name != "name" || references[3].Braced {
+		t.Errorf("Expecting unbraced name reference, got %v", references[3])
+	}
+	_, malformed = configuration.References("cost: $ ${open")
+	if len(malformed) != 2 {
+		t.Errorf("Expecting 2 malformed, got %v", malformed)
+	}
+}
+
+func TestFile_ValidateRegex(t *testing.T) {
+	f := &configuration.File{
+		Type: []string{"go"},
+		Parse: &configuration.Parse{
+			Comment: &core.Comment{
+				Line: "//",
+			},
+		},
+		Modify: &configuration.Modify{
+			Regex: []*core.RegularExpression{
+				{
+					Find:    "(?P<year>\\d{4})-(\\d{2})",
+					Replace: "${year}/${2} $$1",
+				},
+			},
+		},
+	}
+	err := f.Validate()
+	if err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+	f.Modify.Regex[0].Replace = "${month} $3 $yearx $"
+	err = f.Validate()
+	if len(err) != 4 {
+		t.Errorf("Expecting 4 errors, got %v", err)
+	}
+	f.Modify.Regex[0].Find = "(?P<year"
+	err = f.Validate()
+	if len(err) != 1 {
+		t.Errorf("Expecting 1 error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RegoPolicy contains Rego policy modules evaluated with the OPA command line interface; Query defaults to `data.emits.deny` and Command to `opa`
+type RegoPolicy struct {
+	Command string
+	Modules []string
+	Query   string
+}
+
+// regoViolation contains a single violation produced by a Rego rule; rules may also produce plain message strings
+type regoViolation struct {
+	Message  string   `json:"msg"`
+	Pointer  string   `json:"pointer"`
+	Severity Severity `json:"severity"`
+}
+
+// CheckRego returns a Report with a finding for every violation the Rego query produces against the canonical JSON of Configuration as input; a violation is a message or an object of `msg`, `pointer` and `severity`
+func (c *Configuration) CheckRego(policy *RegoPolicy) (*Report, error) {
+	if c == nil {
+		return &Report{Errors: []error{nullConfiguration()}}, nil
+	}
+	if policy == nil {
+		return &Report{}, nil
+	}
+	command, query := policy.Command, policy.Query
+	if len(command) == 0 {
+		command = "opa"
+	}
+	if len(query) == 0 {
+		query = "data.emits.deny"
+	}
+	input, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"eval", "--format", "json", "--stdin-input"}
+	for _, module := range policy.Modules {
+		args = append(args, "--data", module)
+	}
+	cmd := exec.Command(command, append(args, query)...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) > 0 {
+			return nil, fmt.Errorf("could not evaluate rego: %s", message)
+		}
+		return nil, fmt.Errorf("could not evaluate rego: %v", err)
+	}
+	var output struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("could not decode rego result: %v", err)
+	}
+	report := &Report{}
+	for _, result := range output.Result {
+		for _, expression := range result.Expressions {
+			var values []json.RawMessage
+			if err := json.Unmarshal(expression.Value, &values); err != nil {
+				return nil, fmt.Errorf("rego query `%s` must produce a set or array of violations", query)
+			}
+			for _, value := range values {
+				violation := &regoViolation{}
+				if err := json.Unmarshal(value, &violation.Message); err != nil {
+					if err := json.Unmarshal(value, violation); err != nil {
+						return nil, fmt.Errorf("rego violation must be a message or an object: %s", value)
+					}
+				}
+				finding := newError(CodePolicy, violation.Pointer, "%s", violation.Message)
+				if violation.Severity == SeverityWarning {
+					finding.Severity = SeverityWarning
+					report.Warnings = append(report.Warnings, finding)
+				} else {
+					report.Errors = append(report.Errors, finding)
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+// Merge appends the errors and warnings of the other Report, so policy findings combine with the validation Report
+func (r *Report) Merge(other *Report) {
+	if other == nil {
+		return
+	}
+	if r.LoadError == nil {
+		r.LoadError = other.LoadError
+	}
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+}
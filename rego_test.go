@@ -0,0 +1,59 @@
+package configuration_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_CheckRego(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interpreter stub requires a posix shell")
+	}
+	dir := t.TempDir()
+	stub := filepath.Join(dir, "opa")
+	os.WriteFile(stub, []byte("#!/bin/sh\n[ \"$5\" = \"--data\" ] && [ \"$6\" = \"policy.rego\" ] && [ \"$7\" = \"data.emits.deny\" ] && grep -q '\"name\":\"lorem\"' && echo '{\"result\":[{\"expressions\":[{\"value\":[\"plain\",{\"msg\":\"task\",\"pointer\":\"/task/0\"},{\"msg\":\"soft\",\"severity\":\"warning\"}]}]}]}'\n"), 0755)
+	c := &configuration.Configuration{
+		Name: "lorem",
+	}
+	report, err := c.CheckRego(&configuration.RegoPolicy{
+		Command: stub,
+		Modules: []string{"policy.rego"},
+	})
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(report.Errors) != 2 || len(report.Warnings) != 1 {
+		t.Fatalf("Expecting 2 errors and 1 warning, got %v %v", report.Errors, report.Warnings)
+	}
+	if report.Errors[0].Error() != "plain" || configuration.Pointer(report.Errors[1]) != "/task/0" {
+		t.Errorf("Expecting plain and /task/0, got %v %v", report.Errors[0], report.Errors[1])
+	}
+	validation := c.Report()
+	errors := len(validation.Errors)
+	validation.Merge(report)
+	if len(validation.Errors) != errors+2 {
+		t.Errorf("Expecting %v errors, got %v", errors+2, len(validation.Errors))
+	}
+}
+
+func TestConfiguration_CheckRegoOPA(t *testing.T) {
+	command, err := exec.LookPath("opa")
+	if err != nil {
+		t.Skip("opa not installed")
+	}
+	dir := t.TempDir()
+	module := filepath.Join(dir, "policy.rego")
+	os.WriteFile(module, []byte("package emits\n\nimport rego.v1\n\ndeny contains msg if {\n\tnot input.task\n\tmsg := \"at least one task is required\"\n}\n"), 0644)
+	report, err := (&configuration.Configuration{}).CheckRego(&configuration.RegoPolicy{
+		Command: command,
+		Modules: []string{module},
+	})
+	if err != nil || len(report.Errors) != 1 {
+		t.Errorf("Expecting 1 error, got %v %v", report, err)
+	}
+}
@@ -0,0 +1,78 @@
+package configuration
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// pathFields contains the pointer patterns of every string holding a project file path
+var pathFields = []string{
+	"**/plugin/*/path",
+	"**/audit/*/path",
+	"requires/files/*",
+	"extends",
+	"include/*",
+}
+
+// patternFields contains the pointer patterns of every string holding a path pattern, which is always matched relative to the project root
+var patternFields = []string{
+	"**/path/include/*",
+	"**/path/exclude/*",
+	"**/inputs/*",
+	"**/outputs/*",
+	"**/parse/exclude/*",
+	"**/audit/*/include/*",
+	"**/audit/*/exclude/*",
+}
+
+// projectFields contains the pointer patterns of every string holding a project file path or path pattern
+var projectFields = append(append([]string{}, pathFields...), patternFields...)
+
+// visitPaths calls visit with the JSON pointer and settable value of every string within Configuration matching one of the fields
+func (c *Configuration) visitPaths(fields []string, visit func(p string, value reflect.Value)) {
+	visitStrings(reflect.ValueOf(c), "", func(p string, value reflect.Value) {
+		for _, field := range fields {
+			if match(field, strings.TrimPrefix(p, "/")) {
+				visit(p, value)
+				return
+			}
+		}
+	})
+}
+
+// relativize rewrites every absolute path or path pattern under root into a slash separated path relative to root
+func (c *Configuration) relativize(root string) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	c.visitPaths(projectFields, func(p string, value reflect.Value) {
+		path := filepath.FromSlash(value.String())
+		if !filepath.IsAbs(path) {
+			return
+		}
+		relative, err := filepath.Rel(root, path)
+		if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return
+		}
+		value.SetString(filepath.ToSlash(relative))
+	})
+	return nil
+}
+
+// absolutize rewrites every relative file path into an absolute path under root; path patterns stay relative to root, which Task.Files and Plan match them against
+func (c *Configuration) absolutize(root string) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	c.visitPaths(pathFields, func(p string, value reflect.Value) {
+		path := filepath.FromSlash(value.String())
+		if len(path) == 0 || filepath.IsAbs(path) || strings.Contains(path, "://") {
+			return
+		}
+		value.SetString(filepath.ToSlash(filepath.Join(root, path)))
+	})
+	return nil
+}
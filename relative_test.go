@@ -0,0 +1,90 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_WriteProjectRoot(t *testing.T) {
+	wd, _ := os.Getwd()
+	root := t.TempDir()
+	os.Chdir(root)
+	defer os.Chdir(wd)
+	root, _ = os.Getwd()
+	outside := filepath.Join(filepath.Dir(root), "shared", "plugin.js")
+	c := &configuration.Configuration{
+		Name: filepath.Join(root, "name"),
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{filepath.Join(root, "src", "**"), "docs/*.md"},
+				},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{
+							Path: outside,
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := c.Write(configuration.WithProjectRoot(root)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.HasPrefix(c.Task[0].Path.Include[0], root) {
+		t.Errorf("Expecting Configuration unchanged, got %v", c.Task[0].Path.Include[0])
+	}
+	data, _ := os.ReadFile(configuration.ConfigFile)
+	if strings.Contains(string(data), filepath.ToSlash(filepath.Join(root, "src"))) || !strings.Contains(string(data), `"src/**"`) {
+		t.Errorf("Expecting relative include, got %s", data)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if loaded.Name != c.Name || loaded.File[0].Modify.Plugin[0].Path != outside {
+		t.Errorf("Expecting non path and outside path unchanged, got %v %v", loaded.Name, loaded.File[0].Modify.Plugin[0].Path)
+	}
+	loaded = &configuration.Configuration{}
+	if err := loaded.Load(configuration.WithProjectRoot(root)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if loaded.Task[0].Path.Include[1] != "docs/*.md" || loaded.File[0].Modify.Plugin[0].Path != outside {
+		t.Errorf("Expecting relative include and absolute plugin, got %v %v", loaded.Task[0].Path.Include[1], loaded.File[0].Modify.Plugin[0].Path)
+	}
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	os.WriteFile(filepath.Join(root, "docs", "index.md"), nil, 0644)
+	if files, err := loaded.Task[0].Files(root); err != nil || len(files) != 1 || files[0] != "docs/index.md" {
+		t.Errorf("Expecting [docs/index.md], got %v %v", files, err)
+	}
+}
+
+func TestConfiguration_LoadProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "main.go"), nil, 0644)
+	os.WriteFile(filepath.Join(root, "emits.json"), []byte(`{"requires": {"files": ["go.mod"]}, "task": [{"name": "go", "path": {"include": ["*.go", "!main_test.go"]}}], "script": [{"name": "default", "task": ["go"]}], "file": [{"type": ["go"], "parse": {"comment": {"line": "//"}}}]}`), 0644)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(root, configuration.WithProjectRoot(root)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Requires.Files[0] != filepath.ToSlash(filepath.Join(root, "go.mod")) {
+		t.Errorf("Expecting absolute requires file, got %v", c.Requires.Files[0])
+	}
+	if files, err := c.Task[0].Files(root); err != nil || len(files) != 1 || files[0] != "main.go" {
+		t.Errorf("Expecting [main.go], got %v %v", files, err)
+	}
+	if plan, err := c.PlanRoot("default", root); err != nil || len(plan.Step[0].File) != 1 || plan.Step[0].File[0].Path != "main.go" {
+		t.Errorf("Expecting main.go planned, got %v %v", err, plan)
+	}
+}
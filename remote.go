@@ -0,0 +1,237 @@
+package configuration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// HTTPClient is shared by every remote fetch so connections are reused across sources; its Timeout bounds every request and its CheckRedirect applies RemoteMaxRedirects
+	HTTPClient = &http.Client{Timeout: 30 * time.Second, CheckRedirect: checkRedirect}
+	// RemoteMaxRedirects bounds the number of redirects followed by a remote fetch; a redirect from https to http is never followed
+	RemoteMaxRedirects = 10
+	// RemoteMaxBytes bounds the size of every remote document fetched; larger responses fail with ErrTooLarge
+	RemoteMaxBytes int64 = 10 << 20
+	// ErrTooLarge is the error class of every remote document larger than RemoteMaxBytes
+	ErrTooLarge = errors.New("exceeds RemoteMaxBytes")
+	// RemoteWorkers bounds the number of sources and remote files fetched concurrently
+	RemoteWorkers = 8
+)
+
+// SourceError attributes an error to the Source or remote location it occurred at
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+// Error returns the error prefixed with its Source
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("could not load `%s`: %v", e.Source, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// SourceErrors contains every SourceError of a parallel fetch, in source order
+type SourceErrors []*SourceError
+
+// Error returns every SourceError joined
+func (e SourceErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns every SourceError so errors.Is and errors.As match any of them
+func (e SourceErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// parallel calls work for every index below n with at most RemoteWorkers calls running at once
+func parallel(n int, work func(i int)) {
+	workers := RemoteWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	var wait sync.WaitGroup
+	limit := make(chan struct{}, workers)
+	for i := 0; i < n; i++ {
+		wait.Add(1)
+		limit <- struct{}{}
+		go func(i int) {
+			defer wait.Done()
+			defer func() { <-limit }()
+			work(i)
+		}(i)
+	}
+	wait.Wait()
+}
+
+// loadSources returns the Configuration of every Source, loaded concurrently and returned in source order
+func loadSources(sources []Source) ([]*Configuration, error) {
+	layers := make([]*Configuration, len(sources))
+	failures := make([]error, len(sources))
+	parallel(len(sources), func(i int) {
+		layers[i], failures[i] = sources[i].Configuration()
+	})
+	var errs SourceErrors
+	for i, err := range failures {
+		if err != nil {
+			errs = append(errs, &SourceError{Source: sources[i].Name(), Err: err})
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return layers, nil
+}
+
+// fetch returns the body of an HTTP GET of the url made with HTTPClient, bounded by the context and sending the auth headers of the options, and keeps a copy in the cache dir of the options; while Offline only the cached copy is used
+func fetch(o *options, url string) ([]byte, error) {
+	if Offline {
+		return cached(o.cacheDir(), url)
+	}
+	request, err := http.NewRequestWithContext(o.context(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range o.headers(url) {
+		request.Header[name] = values
+	}
+	response, err := HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status `%s`", response.Status)
+	}
+	data, err := readRemote(url, response)
+	if err != nil {
+		return nil, err
+	}
+	store(o.cacheDir(), url, data)
+	return data, nil
+}
+
+// readRemote returns the body of the response to a fetch of the url; returns an error wrapping ErrTooLarge once the body exceeds RemoteMaxBytes
+func readRemote(url string, response *http.Response) ([]byte, error) {
+	if RemoteMaxBytes > 0 && response.ContentLength > RemoteMaxBytes {
+		return nil, fmt.Errorf("`%s` of %d bytes %w of %d bytes", url, response.ContentLength, ErrTooLarge, RemoteMaxBytes)
+	}
+	body := io.Reader(response.Body)
+	if RemoteMaxBytes > 0 {
+		body = io.LimitReader(response.Body, RemoteMaxBytes+1)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if RemoteMaxBytes > 0 && int64(len(data)) > RemoteMaxBytes {
+		return nil, fmt.Errorf("`%s` %w of %d bytes", url, ErrTooLarge, RemoteMaxBytes)
+	}
+	return data, nil
+}
+
+// checkRedirect stops a remote fetch after RemoteMaxRedirects redirects, or at a redirect from https to http; a redirect to another host drops every header of the request, credentials included
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) > RemoteMaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", RemoteMaxRedirects)
+	}
+	if len(via) > 0 && via[len(via)-1].URL.Scheme == "https" && request.URL.Scheme != "https" {
+		return fmt.Errorf("redirect from `%s` to insecure `%s` refused", via[len(via)-1].URL, request.URL)
+	}
+	if len(via) > 0 && !strings.EqualFold(request.URL.Host, via[0].URL.Host) {
+		request.Header = http.Header{}
+	}
+	return nil
+}
+
+// remote returns true if the path is an http or https url
+func remote(p string) bool {
+	return strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://")
+}
+
+// FetchPlugins downloads every remote path of every enabled plugin of every File concurrently into dir, or the UserDirs plugin directory when empty, and returns the local path of each url, caching each download as WithCacheDir selects; errors are attributed to their url
+func (c *Configuration) FetchPlugins(dir string, options ...Option) (map[string]string, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	o := newOptions(options)
+	if len(dir) == 0 {
+		dir = UserDirs.PluginDir()
+	}
+	var urls []string
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		file := c.resolveFile(f)
+		if file.Disabled {
+			continue
+		}
+		for _, plugin := range file.Modify.enabledPlugins() {
+			if remote(plugin.Path) && !contains(urls, plugin.Path) {
+				urls = append(urls, plugin.Path)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	local := make([]string, len(urls))
+	failures := make([]error, len(urls))
+	parallel(len(urls), func(i int) {
+		data, err := fetch(o, urls[i])
+		if err != nil {
+			failures[i] = err
+			return
+		}
+		sum := sha256.Sum256([]byte(urls[i]))
+		name := hex.EncodeToString(sum[:8])
+		if u, err := url.Parse(urls[i]); err == nil {
+			name += path.Ext(u.Path)
+		}
+		local[i] = filepath.Join(dir, name)
+		failures[i] = ioutil.WriteFile(local[i], data, 0644)
+	})
+	plugins := map[string]string{}
+	var errs SourceErrors
+	for i, u := range urls {
+		if failures[i] != nil {
+			errs = append(errs, &SourceError{Source: u, Err: failures[i]})
+			continue
+		}
+		plugins[u] = local[i]
+	}
+	if errs != nil {
+		return plugins, errs
+	}
+	return plugins, nil
+}
+
+// FetchPluginsContext is FetchPlugins bounded by ctx: downloads are made with ctx and fail with its error once ctx is done
+func (c *Configuration) FetchPluginsContext(ctx context.Context, dir string, options ...Option) (map[string]string, error) {
+	return c.FetchPlugins(dir, append(options[:len(options):len(options)], withContext(ctx))...)
+}
@@ -0,0 +1,174 @@
+package configuration_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestCompose_Parallel(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	var active, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if r.URL.Path == "/missing.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+	workers := configuration.RemoteWorkers
+	configuration.RemoteWorkers = 2
+	defer func() { configuration.RemoteWorkers = workers }()
+	var sources []configuration.Source
+	for _, name := range []string{"/a.json", "/b.json", "/c.json", "/d.json"} {
+		sources = append(sources, configuration.URLSource(server.URL+name))
+	}
+	c, _, err := configuration.Compose(sources...)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if c.Name != "/d.json" {
+		t.Errorf("Expecting last layer name, got %v", c.Name)
+	}
+	if peak != 2 {
+		t.Errorf("Expecting 2 concurrent fetches, got %v", peak)
+	}
+	_, _, err = configuration.Compose(append(sources, configuration.URLSource(server.URL+"/missing.json"))...)
+	var errs configuration.SourceErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Source != server.URL+"/missing.json" {
+		t.Errorf("Expecting error attributed to missing source, got %v", err)
+	}
+}
+
+func TestConfiguration_FetchPlugins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("module.exports = {}"))
+	}))
+	defer server.Close()
+	c := &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{Path: server.URL + "/plugin.js"},
+						{Path: "local/plugin.js"},
+					},
+				},
+			},
+		},
+	}
+	dir := filepath.Join(t.TempDir(), "plugins")
+	plugins, err := c.FetchPlugins(dir)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if len(plugins) != 1 {
+		t.Fatalf("Expecting 1 plugin, got %v", plugins)
+	}
+	data, err := os.ReadFile(plugins[server.URL+"/plugin.js"])
+	if err != nil || string(data) != "module.exports = {}" {
+		t.Errorf("Expecting plugin content, got %v %s", err, data)
+	}
+}
+
+func TestConfiguration_FetchPluginsContext(t *testing.T) {
+	cacheDir := configuration.CacheDir
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	c := &configuration.Configuration{
+		File: []*configuration.File{
+			{
+				Type: []string{"js"},
+				Modify: &configuration.Modify{
+					Plugin: []*configuration.Plugin{
+						{Path: server.URL + "/plugin.js"},
+					},
+				},
+			},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.FetchPluginsContext(ctx, t.TempDir())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expecting context deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expecting fetch bounded by the context, took %v", elapsed)
+	}
+}
+
+func TestConfiguration_LoadFrom_URL(t *testing.T) {
+	cacheDir, maxBytes := configuration.CacheDir, configuration.RemoteMaxBytes
+	configuration.CacheDir = t.TempDir()
+	defer func() {
+		configuration.CacheDir = cacheDir
+		configuration.RemoteMaxBytes = maxBytes
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/shared/emits.json":
+			w.Write([]byte(`{"name": "shared", "include": ["tasks.json"]}`))
+		case "/shared/tasks.json":
+			w.Write([]byte(`{"task": [{"name": "docs", "path": {"include": ["*.go"]}}]}`))
+		case "/moved.json":
+			http.Redirect(w, r, "/shared/tasks.json", http.StatusFound)
+		case "/loop.json":
+			http.Redirect(w, r, "/loop.json", http.StatusFound)
+		case "/large.json":
+			w.Write([]byte(`{"name": "` + strings.Repeat("a", 100) + `"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(server.URL + "/shared/emits.json"); err != nil || c.Name != "shared" || c.FindTask("docs") == nil {
+		t.Errorf("Expecting shared with docs task, got %v %v", err, c)
+	}
+	c = &configuration.Configuration{}
+	if err := c.LoadFrom(server.URL + "/moved.json"); err != nil || c.FindTask("docs") == nil {
+		t.Errorf("Expecting redirected docs task, got %v %v", err, c.Task)
+	}
+	if err := (&configuration.Configuration{}).LoadFrom(server.URL + "/loop.json"); err == nil {
+		t.Errorf("Expecting redirect error, got nil")
+	}
+	configuration.RemoteMaxBytes = 50
+	if err := (&configuration.Configuration{}).LoadFrom(server.URL + "/large.json"); !errors.Is(err, configuration.ErrTooLarge) {
+		t.Errorf("Expecting ErrTooLarge, got %v", err)
+	}
+}
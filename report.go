@@ -0,0 +1,105 @@
+package configuration
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Report contains the outcome of loading and validating a single configuration file
+type Report struct {
+	Path      string
+	LoadError error
+	Errors    []error
+	Warnings  []error
+}
+
+// Valid returns true if the configuration file loaded and produced no validation errors
+func (r *Report) Valid() bool {
+	if r == nil {
+		return true
+	}
+	return r.LoadError == nil && len(r.Errors) == 0
+}
+
+// Report returns the findings of Validate and Lint separated by Severity, so callers may fail on errors only
+func (c *Configuration) Report(options ...Option) *Report {
+	report := &Report{}
+	for _, finding := range append(c.Validate(options...), c.Lint()...) {
+		if ErrorSeverity(finding) == SeverityWarning {
+			report.Warnings = append(report.Warnings, finding)
+		} else {
+			report.Errors = append(report.Errors, finding)
+		}
+	}
+	return report
+}
+
+// ValidateAll loads every path with LoadFrom and validates it, concurrently; a load failure is isolated to the Report of its path
+func ValidateAll(paths []string) map[string]*Report {
+	reports := make(map[string]*Report, len(paths))
+	var mutex sync.Mutex
+	var wait sync.WaitGroup
+	limit := make(chan struct{}, runtime.NumCPU())
+	for _, path := range paths {
+		wait.Add(1)
+		go func(path string) {
+			defer wait.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			report := &Report{
+				Path: path,
+			}
+			c := &Configuration{}
+			report.LoadError = c.LoadFrom(path)
+			if report.LoadError == nil {
+				validation := c.Report()
+				report.Errors = validation.Errors
+				report.Warnings = validation.Warnings
+			}
+			mutex.Lock()
+			reports[path] = report
+			mutex.Unlock()
+		}(path)
+	}
+	wait.Wait()
+	return reports
+}
+
+const (
+	// ExitOK constant for the exit code of a Report that passes its ExitPolicy
+	ExitOK = 0
+	// ExitErrors constant for the exit code of a Report with validation errors
+	ExitErrors = 1
+	// ExitWarnings constant for the exit code of a Report failed by its warnings alone
+	ExitWarnings = 2
+	// ExitLoad constant for the exit code of a Report whose configuration file could not be loaded
+	ExitLoad = 3
+)
+
+// ExitPolicy configures how Report.ExitCode maps findings to an exit code, so every command line wrapping this package agrees on `--strict` and `--quiet`
+type ExitPolicy struct {
+	// Strict fails on any warning
+	Strict bool
+	// MaxWarnings fails once warnings exceed it; zero or less never fails on warnings unless Strict
+	MaxWarnings int
+	// Quiet never fails on warnings, overriding Strict and MaxWarnings
+	Quiet bool
+}
+
+// ExitCode returns ExitLoad if the configuration file could not be loaded, ExitErrors if it has validation errors, ExitWarnings if its warnings fail the policy, otherwise ExitOK
+func (r *Report) ExitCode(policy ExitPolicy) int {
+	if r == nil {
+		return ExitOK
+	}
+	switch {
+	case r.LoadError != nil:
+		return ExitLoad
+	case len(r.Errors) > 0:
+		return ExitErrors
+	case policy.Quiet || len(r.Warnings) == 0:
+		return ExitOK
+	case policy.Strict, policy.MaxWarnings > 0 && len(r.Warnings) > policy.MaxWarnings:
+		return ExitWarnings
+	}
+	return ExitOK
+}
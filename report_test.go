@@ -0,0 +1,66 @@
+package configuration_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestValidateAll(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	broken := filepath.Join(dir, "broken.json")
+	missing := filepath.Join(dir, "missing.json")
+	project := filepath.Join(dir, "project")
+	os.Mkdir(project, 0755)
+	os.WriteFile(filepath.Join(project, "emits.json"), []byte(`{"var":{"lang":"go"},"task":[{"name":"lorem","path":{"include":["*"]}}],"file":[{"type":["{{var.lang}}"],"parse":{"comment":{"line":"//"}}}]}`), 0644)
+	os.WriteFile(valid, []byte(`{"task":[{"name":"lorem","path":{"include":["*"]}}],"file":[{"type":["go"],"parse":{"comment":{"line":"//"}}}]}`), 0644)
+	os.WriteFile(invalid, []byte(`{"task":[{"name":"lorem"}]}`), 0644)
+	os.WriteFile(broken, []byte(`{"task":`), 0644)
+	reports := configuration.ValidateAll([]string{valid, invalid, broken, missing, project})
+	if len(reports) != 5 {
+		t.Fatalf("Expecting 5 reports, got %v", len(reports))
+	}
+	if !reports[project].Valid() {
+		t.Errorf("Expecting the directory loaded like LoadFrom, got %v %v", reports[project].LoadError, reports[project].Errors)
+	}
+	if !reports[valid].Valid() {
+		t.Errorf("Expecting valid, got %v %v", reports[valid].LoadError, reports[valid].Errors)
+	}
+	if reports[invalid].Valid() || reports[invalid].LoadError != nil || len(reports[invalid].Errors) == 0 {
+		t.Errorf("Expecting validation errors, got %v %v", reports[invalid].LoadError, reports[invalid].Errors)
+	}
+	if reports[broken].LoadError == nil {
+		t.Errorf("Expecting load error, got nil")
+	}
+	if reports[missing].LoadError == nil {
+		t.Errorf("Expecting load error, got nil")
+	}
+}
+
+func TestReport_ExitCode(t *testing.T) {
+	warning := errors.New("warning")
+	cases := []struct {
+		report   *configuration.Report
+		policy   configuration.ExitPolicy
+		expected int
+	}{
+		{&configuration.Report{}, configuration.ExitPolicy{Strict: true}, configuration.ExitOK},
+		{&configuration.Report{LoadError: warning, Errors: []error{warning}}, configuration.ExitPolicy{}, configuration.ExitLoad},
+		{&configuration.Report{Errors: []error{warning}}, configuration.ExitPolicy{Quiet: true}, configuration.ExitErrors},
+		{&configuration.Report{Warnings: []error{warning}}, configuration.ExitPolicy{}, configuration.ExitOK},
+		{&configuration.Report{Warnings: []error{warning}}, configuration.ExitPolicy{Strict: true}, configuration.ExitWarnings},
+		{&configuration.Report{Warnings: []error{warning}}, configuration.ExitPolicy{Strict: true, Quiet: true}, configuration.ExitOK},
+		{&configuration.Report{Warnings: []error{warning, warning}}, configuration.ExitPolicy{MaxWarnings: 2}, configuration.ExitOK},
+		{&configuration.Report{Warnings: []error{warning, warning, warning}}, configuration.ExitPolicy{MaxWarnings: 2}, configuration.ExitWarnings},
+	}
+	for i, c := range cases {
+		if code := c.report.ExitCode(c.policy); code != c.expected {
+			t.Errorf("Expecting %v for case %v, got %v", c.expected, i, code)
+		}
+	}
+}
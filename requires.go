@@ -0,0 +1,70 @@
+package configuration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Requires contains the prerequisites of Configuration checked by Preflight before any processing starts
+type Requires struct {
+	Env      []string `json:"env,omitempty"`
+	Commands []string `json:"commands,omitempty"`
+	Files    []string `json:"files,omitempty"`
+}
+
+// Validate returns all known errors of Requires
+func (r *Requires) Validate() []error {
+	var errors []error
+	if r == nil {
+		return errors
+	}
+	for _, required := range []struct {
+		key    string
+		values []string
+	}{
+		{"env", r.Env},
+		{"commands", r.Commands},
+		{"files", r.Files},
+	} {
+		for i, value := range required.values {
+			if len(strings.TrimSpace(value)) == 0 {
+				errors = append(errors, newError(CodeEmpty, pointer(required.key, i), "requires %s definition at index `%v` is empty", required.key, i))
+			}
+		}
+	}
+	return errors
+}
+
+// Preflight returns an error for every Requires prerequisite missing from the environment, PATH or current directory
+func (c *Configuration) Preflight() []error {
+	return c.PreflightRoot(".")
+}
+
+// PreflightRoot returns an error for every Requires prerequisite missing from the environment, PATH or root
+func (c *Configuration) PreflightRoot(root string) []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	var errors []error
+	if c.Requires == nil {
+		return errors
+	}
+	for i, env := range c.Requires.Env {
+		if _, ok := os.LookupEnv(env); !ok {
+			errors = append(errors, newError(CodeUnavailable, pointer("requires", "env", i), "required environment variable `%s` is not set", env))
+		}
+	}
+	for i, command := range c.Requires.Commands {
+		if _, err := exec.LookPath(command); err != nil {
+			errors = append(errors, newError(CodeUnavailable, pointer("requires", "commands", i), "required command `%s` is not available", command))
+		}
+	}
+	for i, file := range c.Requires.Files {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(file))); err != nil {
+			errors = append(errors, newError(CodeUnavailable, pointer("requires", "files", i), "required file `%s` does not exist", file))
+		}
+	}
+	return errors
+}
@@ -0,0 +1,33 @@
+package configuration_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Preflight(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(dir+"/package.json", []byte(`{}`), 0644)
+	os.Setenv("EMITS_TEST_TOKEN", "secret")
+	defer os.Unsetenv("EMITS_TEST_TOKEN")
+	c := &configuration.Configuration{
+		Requires: &configuration.Requires{
+			Env:      []string{"EMITS_TEST_TOKEN", "EMITS_TEST_UNSET"},
+			Commands: []string{"go", "emits-test-missing-command"},
+			Files:    []string{"package.json", "missing.json"},
+		},
+	}
+	errs := c.PreflightRoot(dir)
+	if len(errs) != 3 {
+		t.Fatalf("Expecting 3 errors, got %v", errs)
+	}
+	if configuration.Pointer(errs[1]) != "/requires/commands/1" {
+		t.Errorf("Expecting /requires/commands/1, got %v", configuration.Pointer(errs[1]))
+	}
+	c.Requires.Env = append(c.Requires.Env, " ")
+	if errs := c.Requires.Validate(); len(errs) != 1 {
+		t.Errorf("Expecting 1 error, got %v", errs)
+	}
+}
@@ -0,0 +1,147 @@
+package configuration
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FidelityReport contains the differences between a configuration file and its re-serialization by Configuration
+type FidelityReport struct {
+	Path       string
+	Semantic   []string
+	Formatting []int
+	Output     []byte
+}
+
+// Lossless returns true if the re-serialization holds every value of the file
+func (r *FidelityReport) Lossless() bool {
+	return len(r.Semantic) == 0
+}
+
+// Identical returns true if the re-serialization is byte-for-byte the file
+func (r *FidelityReport) Identical() bool {
+	return r.Lossless() && len(r.Formatting) == 0
+}
+
+// CheckRoundTrip loads the file at path, re-serializes it in the same format and reports the JSON pointer of every value lost or changed, treating empty values as absent, and the line number of every original line not kept verbatim, such as comments and custom indentation
+func CheckRoundTrip(path string) (*FidelityReport, error) {
+	original, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Configuration{}
+	if err := c.decodeFile(path); err != nil {
+		return nil, err
+	}
+	codec := codecFor(path)
+	output, _, err := c.encode(codec, newOptions(nil))
+	if err != nil {
+		return nil, err
+	}
+	before, err := genericValue(original, Format(codec.Name()))
+	if err != nil {
+		return nil, err
+	}
+	after, err := genericValue(output, Format(codec.Name()))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode re-serialization: %v", err)
+	}
+	report := &FidelityReport{
+		Path:   path,
+		Output: output,
+	}
+	semanticDiff(before, after, "", &report.Semantic)
+	report.Formatting = changedLines(original, output)
+	return report, nil
+}
+
+// genericValue returns the document decoded into maps, slices and scalars regardless of Format
+func genericValue(document []byte, format Format) (interface{}, error) {
+	if format == JSONC {
+		document, format = StripJSONC(document), JSON
+	}
+	node, err := decodeNode(document, format)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// semanticDiff appends the JSON pointer of every value that differs between the decoded documents; empty values equal absent ones
+func semanticDiff(before, after interface{}, p string, differences *[]string) {
+	if empty(before) && empty(after) {
+		return
+	}
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, ok := after.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(b)+len(a))
+		for key := range b {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := b[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			semanticDiff(b[key], a[key], p+pointer(key), differences)
+		}
+		return
+	case []interface{}:
+		a, ok := after.([]interface{})
+		if !ok || len(a) != len(b) {
+			break
+		}
+		for i := range b {
+			semanticDiff(b[i], a[i], p+pointer(i), differences)
+		}
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		*differences = append(*differences, p)
+	}
+}
+
+// empty returns true if the decoded value is omitted when Configuration is encoded
+func empty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// changedLines returns the 1-based number of every line of original missing from the longest common subsequence of lines with output
+func changedLines(original, output []byte) []int {
+	a := strings.Split(strings.TrimRight(string(original), "\n"), "\n")
+	b := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	var lines []int
+	for _, edit := range diffLines(a, b) {
+		if edit.op == '-' {
+			lines = append(lines, edit.index+1)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,41 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestCheckRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	os.WriteFile(path, []byte("{\n\t\"name\": \"lorem\",\n\t\"task\": [\n\t\t{\n\t\t\t\"name\": \"docs\"\n\t\t}\n\t]\n}\n"), 0644)
+	report, err := configuration.CheckRoundTrip(path)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !report.Identical() {
+		t.Errorf("Expecting identical, got %+v", report)
+	}
+	path = filepath.Join(dir, "emits.jsonc")
+	os.WriteFile(path, []byte("{\n\t// project\n\t\"name\": \"lorem\",\n\t\"nmae\": \"ipsum\",\n\t\"version\": \"\",\n\t\"task\": [\n\t\t{\n\t\t\t\"name\": \"docs\"\n\t\t}\n\t]\n}\n"), 0644)
+	report, err = configuration.CheckRoundTrip(path)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(report.Semantic, []string{"/nmae"}) {
+		t.Errorf("Expecting [/nmae], got %v", report.Semantic)
+	}
+	if !reflect.DeepEqual(report.Formatting, []int{2, 4, 5}) {
+		t.Errorf("Expecting [2 4 5], got %v", report.Formatting)
+	}
+	if report.Lossless() || report.Identical() {
+		t.Errorf("Expecting lossy, got %+v", report)
+	}
+	if _, err := configuration.CheckRoundTrip(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
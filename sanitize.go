@@ -0,0 +1,80 @@
+package configuration
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// Redacted constant for the placeholder Sanitize substitutes for secrets
+	Redacted = "REDACTED"
+)
+
+var (
+	// sensitiveEnv matches environment variable names whose values Sanitize never lets through
+	sensitiveEnv = regexp.MustCompile(`(?i)(token|secret|password|passwd|key|auth|credential|session|cookie)`)
+	// userPath matches the home directory of any user on common platforms
+	userPath = regexp.MustCompile(`(/home/|/Users/|[A-Za-z]:\\Users\\)[^/\\\s"]+`)
+	// rawURL matches an absolute URL within a string value
+	rawURL = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s"]+`)
+)
+
+// Sanitize returns a copy of Configuration safe to attach to bug reports: values of sensitive environment variables become `{{env.NAME}}` references, URL credentials and auth query values are redacted, and user home paths are anonymized
+func (c *Configuration) Sanitize() *Configuration {
+	sanitized := c.Clone()
+	secrets := sensitiveValues()
+	home, _ := os.UserHomeDir()
+	visitStrings(reflect.ValueOf(sanitized), "", func(p string, value reflect.Value) {
+		s := value.String()
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret[1], "{{env."+secret[0]+"}}")
+		}
+		s = rawURL.ReplaceAllStringFunc(s, redactURL)
+		if len(home) > 1 {
+			s = strings.ReplaceAll(s, home, "~")
+		}
+		s = userPath.ReplaceAllString(s, "${1}user")
+		value.SetString(s)
+	})
+	return sanitized
+}
+
+// sensitiveValues returns the name and value of every set environment variable with a sensitive name, longest value first so overlapping values redact fully
+func sensitiveValues() [][2]string {
+	var secrets [][2]string
+	for _, env := range os.Environ() {
+		pair := strings.SplitN(env, "=", 2)
+		if len(pair) == 2 && len(pair[1]) >= 4 && sensitiveEnv.MatchString(pair[0]) {
+			secrets = append(secrets, [2]string{pair[0], pair[1]})
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i][1]) > len(secrets[j][1])
+	})
+	return secrets
+}
+
+// redactURL returns the URL with its user information and the values of sensitive query parameters redacted
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User(Redacted)
+	}
+	query := u.Query()
+	for key := range query {
+		if sensitiveEnv.MatchString(key) {
+			query.Set(key, Redacted)
+		}
+	}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,120 @@
+package configuration
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema.json
+var schemaDocument []byte
+
+// jsonSchema contains the subset of JSON Schema used by the embedded schema
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+var (
+	schemaOnce sync.Once
+	schemaRoot *jsonSchema
+)
+
+// JSONSchema returns the JSON Schema of ConfigFile embedded in this package, for editors and external validators
+func JSONSchema() []byte {
+	return append([]byte(nil), schemaDocument...)
+}
+
+// embeddedSchema returns the embedded JSON Schema, parsed once
+func embeddedSchema() *jsonSchema {
+	schemaOnce.Do(func() {
+		schemaRoot = &jsonSchema{}
+		if err := json.Unmarshal(schemaDocument, schemaRoot); err != nil {
+			panic(err)
+		}
+	})
+	return schemaRoot
+}
+
+// ValidateDocument returns a ValidationError for every value of the document whose type differs from the embedded JSON Schema, before unmarshalling silently drops or mangles it; unknown keys are reported by UnknownFields and null values are accepted
+func ValidateDocument(document []byte, format Format) ([]error, error) {
+	if format == JSONC {
+		document, format = StripJSONC(document), JSON
+	}
+	node, err := decodeNode(document, format)
+	if err != nil {
+		return nil, err
+	}
+	var errors []error
+	root := embeddedSchema()
+	validateNode(node, root, root, "", &errors)
+	return errors, nil
+}
+
+// validateNode appends an error for every value below node whose type differs from the schema s
+func validateNode(node *yaml.Node, s *jsonSchema, root *jsonSchema, p string, errors *[]error) {
+	if s.Ref == "#" {
+		s = root
+	} else if strings.HasPrefix(s.Ref, "#/$defs/") {
+		s = root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+	actual := nodeType(node)
+	if s == nil || actual == "null" {
+		return
+	}
+	if actual != s.Type && !(actual == "integer" && s.Type == "number") {
+		element := p
+		if len(element) == 0 {
+			element = "/"
+		}
+		*errors = append(*errors, newError(CodeType, p, "`%s` expects %s, got %s", element, s.Type, actual))
+		return
+	}
+	switch actual {
+	case "object":
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if property, ok := s.Properties[key]; ok {
+				validateNode(node.Content[i+1], property, root, p+pointer(key), errors)
+			} else if s.AdditionalProperties != nil {
+				validateNode(node.Content[i+1], s.AdditionalProperties, root, p+pointer(key), errors)
+			}
+		}
+	case "array":
+		if s.Items != nil {
+			for i, child := range node.Content {
+				validateNode(child, s.Items, root, p+pointer(i), errors)
+			}
+		}
+	}
+}
+
+// nodeType returns the JSON Schema type name of the node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.AliasNode:
+		return nodeType(node.Alias)
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	}
+	return "string"
+}
@@ -0,0 +1,127 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *schemaNode            `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+}
+
+// schemaDrift reports every difference between the Go type and the schema node
+func schemaDrift(t *testing.T, typ reflect.Type, node *schemaNode, defs map[string]*schemaNode, p string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if node.Ref == "#" {
+		if typ.Name() != "Configuration" {
+			t.Errorf("Expecting %v to reference Configuration, got #", p)
+		}
+		return
+	}
+	if strings.HasPrefix(node.Ref, "#/$defs/") {
+		name := strings.TrimPrefix(node.Ref, "#/$defs/")
+		if name != typ.Name() {
+			t.Errorf("Expecting %v to reference %v, got %v", p, typ.Name(), name)
+		}
+		node = defs[name]
+		if node == nil {
+			t.Errorf("Expecting %v definition, got nil", name)
+			return
+		}
+	}
+	expected := map[reflect.Kind]string{
+		reflect.Struct: "object",
+		reflect.Map:    "object",
+		reflect.Slice:  "array",
+		reflect.String: "string",
+		reflect.Bool:   "boolean",
+		reflect.Int:    "integer",
+	}[typ.Kind()]
+	if node.Type != expected {
+		t.Errorf("Expecting %v of type %v, got %v", p, expected, node.Type)
+		return
+	}
+	switch typ.Kind() {
+	case reflect.Slice:
+		schemaDrift(t, typ.Elem(), node.Items, defs, p+"/items")
+	case reflect.Map:
+		schemaDrift(t, typ.Elem(), node.AdditionalProperties, defs, p+"/additionalProperties")
+	case reflect.Struct:
+		fields := map[string]bool{}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" || field.PkgPath != "" {
+				continue
+			}
+			fields[name] = true
+			property := node.Properties[name]
+			if property == nil {
+				t.Errorf("Expecting %v/%v in schema.json, got nil", p, name)
+				continue
+			}
+			schemaDrift(t, field.Type, property, defs, p+"/"+name)
+		}
+		for name := range node.Properties {
+			if !fields[name] {
+				t.Errorf("Expecting %v/%v removed from schema.json, got %v", p, name, typ.Name())
+			}
+		}
+	}
+}
+
+func TestJSONSchema_Drift(t *testing.T) {
+	root := &schemaNode{}
+	if err := json.Unmarshal(configuration.JSONSchema(), root); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	schemaDrift(t, reflect.TypeOf(configuration.Configuration{}), root, root.Defs, "")
+}
+
+func TestValidateDocument(t *testing.T) {
+	document := []byte(`{"name": "lorem", "task": [{"name": "ipsum", "path": {"include": "*.go"}, "disabled": "yes"}], "file": [{"parse": {"comment": {"block": null}}}], "unknown": 1}`)
+	errs, err := configuration.ValidateDocument(document, configuration.JSON)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	expected := []string{"/task/0/path/include", "/task/0/disabled"}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expecting %v errors, got %v", len(expected), errs)
+	}
+	for i := range expected {
+		if configuration.Pointer(errs[i]) != expected[i] || configuration.ErrorCode(errs[i]) != configuration.CodeType {
+			t.Errorf("Expecting %v, got %v", expected[i], errs[i])
+		}
+	}
+	if !strings.Contains(errs[0].Error(), "expects array, got string") {
+		t.Errorf("Expecting array expectation, got %v", errs[0])
+	}
+	errs, _ = configuration.ValidateDocument([]byte("task:\n  - name: 1\n"), configuration.YAML)
+	if len(errs) != 1 || configuration.Pointer(errs[0]) != "/task/0/name" {
+		t.Errorf("Expecting /task/0/name, got %v", errs)
+	}
+}
+
+func TestWithSchemaValidation(t *testing.T) {
+	c := &configuration.Configuration{}
+	err := c.LoadReader(strings.NewReader(`{"task": [{"name": ["lorem"]}]}`), configuration.WithSchemaValidation())
+	var e *configuration.ValidationError
+	if !errors.As(err, &e) || e.Pointer != "/task/0/name" {
+		t.Errorf("Expecting /task/0/name, got %v", err)
+	}
+	if err = c.LoadReader(strings.NewReader(`{"task": [{"name": "lorem"}]}`), configuration.WithSchemaValidation()); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+}
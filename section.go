@@ -0,0 +1,184 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WriteSection patches only the section of the configuration file Load would select; see WriteSectionToPath
+func (c *Configuration) WriteSection(section string, options ...Option) error {
+	return c.WriteSectionToPath(ConfigFileFS(os.DirFS(".")), section, options...)
+}
+
+// WriteSectionToPath replaces the section, a top-level key such as `version` or a JSON pointer such as `/task/2`, of the file at path with its value on Configuration; every other byte of a JSON or JSONC file is preserved, files of other formats or without the section's parent are written whole
+func (c *Configuration) WriteSectionToPath(path string, section string, options ...Option) error {
+	if !strings.HasPrefix(section, "/") {
+		section = pointer(section)
+	}
+	o := newOptions(options)
+	path, codec, err := writeTarget(path, o)
+	if err != nil {
+		return err
+	}
+	unlock, err := acquireLock(o.cacheDir(), path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	original, err := ioutil.ReadFile(path)
+	if err != nil || codec.Name() != string(JSON) && codec.Name() != string(JSONC) {
+		return c.writeFile(path, codec, o)
+	}
+	encoded, _, err := c.encode(FindCodec(JSON), o)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+	value, ok := valueAt(document, section)
+	if !ok {
+		return fmt.Errorf("`%s` section is not set on Configuration", section)
+	}
+	stripped := StripJSONC(original)
+	if !json.Valid(stripped) {
+		return c.writeFile(path, codec, o)
+	}
+	s := &scanner{
+		data:   stripped,
+		offset: map[string][2]int{},
+	}
+	s.value("")
+	patched, ok := patchSection(original, stripped, s.offset, section, value)
+	if !ok {
+		return c.writeFile(path, codec, o)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := rotateBackups(path, patched, o.backups); err != nil {
+		return err
+	}
+	return writeAtomic(path, patched, info.Mode().Perm())
+}
+
+// valueAt returns the value of the decoded JSON document at the JSON pointer
+func valueAt(document interface{}, p string) (interface{}, bool) {
+	value := document
+	for _, token := range tokens(p) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// patchSection returns the document with the value at the JSON pointer replaced, or added to its parent object after any trailing comment or comma, indented like the surrounding lines; stripped is the document without comments and trailing commas, at the same offsets; returns false when the parent is not an object of the document
+func patchSection(document []byte, stripped []byte, offset map[string][2]int, p string, value interface{}) ([]byte, bool) {
+	unit := indentUnit(document)
+	if current, ok := offset[p]; ok {
+		data, err := json.MarshalIndent(value, lineIndent(document, current[0]), unit)
+		if err != nil {
+			return nil, false
+		}
+		return splice(document, current[0], current[1], data), true
+	}
+	i := strings.LastIndex(p, "/")
+	parent, ok := offset[p[:i]]
+	if !ok || document[parent[0]] != '{' {
+		return nil, false
+	}
+	key, _ := json.Marshal(tokens(p)[len(tokens(p))-1])
+	indent := lineIndent(document, parent[0]) + unit
+	data, err := json.MarshalIndent(value, indent, unit)
+	if err != nil {
+		return nil, false
+	}
+	member := "\n" + indent + string(key) + ": " + string(data)
+	last := parent[1] - 2
+	for last > parent[0] && strings.ContainsRune(" \t\r\n", rune(stripped[last])) {
+		last--
+	}
+	end := parent[1] - 2
+	for end > last && strings.ContainsRune(" \t\r\n", rune(document[end])) {
+		end--
+	}
+	if last == parent[0] && end == last {
+		return splice(document, last+1, parent[1]-1, []byte(member+"\n"+lineIndent(document, parent[0]))), true
+	}
+	patched := splice(document, end+1, end+1, []byte(member))
+	if last == parent[0] || trailingComma(document, last+1, end+1) {
+		return patched, true
+	}
+	return splice(patched, last+1, last+1, []byte(",")), true
+}
+
+// trailingComma returns true if the bytes of the document from start to end, holding only whitespace and comments otherwise, hold a comma
+func trailingComma(document []byte, start int, end int) bool {
+	for i := start; i < end; i++ {
+		switch {
+		case document[i] == ',':
+			return true
+		case document[i] == '/' && i+1 < end && document[i+1] == '/':
+			for i < end && document[i] != '\n' {
+				i++
+			}
+		case document[i] == '/' && i+1 < end && document[i+1] == '*':
+			for i += 2; i+1 < end && !(document[i] == '*' && document[i+1] == '/'); i++ {
+			}
+			i++
+		}
+	}
+	return false
+}
+
+// splice returns a copy of the document with the bytes from start to end replaced by data
+func splice(document []byte, start int, end int, data []byte) []byte {
+	patched := make([]byte, 0, len(document)-(end-start)+len(data))
+	patched = append(patched, document[:start]...)
+	patched = append(patched, data...)
+	return append(patched, document[end:]...)
+}
+
+// lineIndent returns the leading whitespace of the line holding the byte offset
+func lineIndent(document []byte, offset int) string {
+	start := bytes.LastIndexByte(document[:offset], '\n') + 1
+	end := start
+	for end < len(document) && (document[end] == ' ' || document[end] == '\t') {
+		end++
+	}
+	return string(document[start:end])
+}
+
+// indentUnit returns the indentation of the first indented line of the document, or a tab
+func indentUnit(document []byte) string {
+	for _, line := range bytes.Split(document, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return "\t"
+}
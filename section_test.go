@@ -0,0 +1,67 @@
+package configuration_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_WriteSectionToPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "emits.jsonc")
+	original := "{\n  // hand written\n  \"name\":   \"lorem\",\n  \"version\": \"1.0.0\",\n  \"task\": [\n    {\"name\": \"docs\", \"path\": {\"include\": [\"*.go\"]}}\n  ]\n}\n"
+	os.WriteFile(path, []byte(original), 0600)
+	c := &configuration.Configuration{}
+	if err := c.LoadFrom(path); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	c.Version = "1.1.0"
+	c.Task[0].Path.Include = []string{"**/*.go"}
+	c.Description = "ipsum"
+	for _, section := range []string{"version", "/task/0/path/include", "description"} {
+		if err := c.WriteSectionToPath(path, section); err != nil {
+			t.Fatalf("Expecting nil, got %v", err)
+		}
+	}
+	expected := "{\n  // hand written\n  \"name\":   \"lorem\",\n  \"version\": \"1.1.0\",\n  \"task\": [\n    {\"name\": \"docs\", \"path\": {\"include\": [\n      \"**/*.go\"\n    ]}}\n  ],\n  \"description\": \"ipsum\"\n}\n"
+	data, _ := os.ReadFile(path)
+	if string(data) != expected {
+		t.Errorf("Expecting\n%v\ngot\n%v", expected, string(data))
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("Expecting 0600, got %v", info.Mode().Perm())
+	}
+	if err := c.WriteSectionToPath(path, "author"); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestConfiguration_WriteSectionToPath_JSONC(t *testing.T) {
+	for original, expected := range map[string]string{
+		"{\n  \"name\": \"x\" // note\n}\n":             "{\n  \"name\": \"x\", // note\n  \"version\": \"1.0.0\"\n}\n",
+		"{\n  \"name\": \"x\",\n}\n":                    "{\n  \"name\": \"x\",\n  \"version\": \"1.0.0\"\n}\n",
+		"{\n  \"name\": \"x\", /* a, b */ // c, d\n}\n": "{\n  \"name\": \"x\", /* a, b */ // c, d\n  \"version\": \"1.0.0\"\n}\n",
+		"{\n  \"name\": \"x\" /* a, b */ // c, d\n}\n":  "{\n  \"name\": \"x\", /* a, b */ // c, d\n  \"version\": \"1.0.0\"\n}\n",
+		"{\n  // only a comment\n}\n":                   "{\n  // only a comment\n  \"version\": \"1.0.0\"\n}\n",
+	} {
+		path := filepath.Join(t.TempDir(), "emits.jsonc")
+		os.WriteFile(path, []byte(original), 0644)
+		c := &configuration.Configuration{}
+		if err := c.LoadFrom(path); err != nil {
+			t.Fatalf("Expecting nil, got %v", err)
+		}
+		c.Version = "1.0.0"
+		if err := c.WriteSectionToPath(path, "version"); err != nil {
+			t.Fatalf("Expecting nil, got %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		if string(data) != expected {
+			t.Errorf("Expecting\n%v\ngot\n%v", expected, string(data))
+		}
+		reloaded := &configuration.Configuration{}
+		if err := reloaded.LoadFrom(path); err != nil || reloaded.Version != "1.0.0" {
+			t.Errorf("Expecting reloaded version, got %v %v", err, reloaded.Version)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package configuration
+
+import (
+	"errors"
+	"path"
+)
+
+var (
+	// ErrMissing matches every ValidationError of CodeMissing
+	ErrMissing = errors.New("missing definition")
+	// ErrEmpty matches every ValidationError of CodeEmpty
+	ErrEmpty = errors.New("empty definition")
+	// ErrInvalid matches every ValidationError of CodeInvalid
+	ErrInvalid = errors.New("invalid definition")
+	// ErrDuplicate matches every ValidationError of CodeDuplicate
+	ErrDuplicate = errors.New("duplicate definition")
+	// ErrUnknown matches every ValidationError of CodeUnknown
+	ErrUnknown = errors.New("unknown reference")
+	// ErrConflict matches every ValidationError of CodeConflict
+	ErrConflict = errors.New("conflicting definitions")
+	// ErrExtends matches every ValidationError of CodeExtends
+	ErrExtends = errors.New("unresolved extends reference")
+	// ErrMissingTask matches the ValidationError of a configuration without task definitions
+	ErrMissingTask = errors.New("missing task definition")
+	// ErrMissingFile matches the ValidationError of a configuration without file definitions
+	ErrMissingFile = errors.New("missing file definition")
+	// ErrUnknownTaskRef matches the ValidationError of a script referencing an unknown task
+	ErrUnknownTaskRef = errors.New("unknown task reference")
+)
+
+// sentinels maps every sentinel error to the Code and, when set, the JSON pointer pattern of the ValidationError it matches
+var sentinels = map[error]struct {
+	code    Code
+	pattern string
+}{
+	ErrMissing:        {CodeMissing, ""},
+	ErrEmpty:          {CodeEmpty, ""},
+	ErrInvalid:        {CodeInvalid, ""},
+	ErrDuplicate:      {CodeDuplicate, ""},
+	ErrUnknown:        {CodeUnknown, ""},
+	ErrConflict:       {CodeConflict, ""},
+	ErrExtends:        {CodeExtends, ""},
+	ErrMissingTask:    {CodeMissing, "/task"},
+	ErrMissingFile:    {CodeMissing, "/file"},
+	ErrUnknownTaskRef: {CodeUnknown, "/script/*/task/*"},
+}
+
+// Is returns true if the target is a sentinel error matching the Code and JSON pointer of ValidationError; used by errors.Is
+func (e *ValidationError) Is(target error) bool {
+	s, ok := sentinels[target]
+	if !ok || s.code != e.Code {
+		return false
+	}
+	if len(s.pattern) == 0 {
+		return true
+	}
+	matched, _ := path.Match(s.pattern, e.Pointer)
+	return matched
+}
+
+// ValidateErr returns all known validation errors as Errors, or nil if valid; use errors.Is with the sentinel errors or errors.As with ValidationError to branch on specific failures
+func (c *Configuration) ValidateErr(options ...Option) error {
+	if errs := c.Validate(options...); len(errs) > 0 {
+		return Errors(errs)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package configuration_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func validConfiguration() *configuration.Configuration {
+	return &configuration.Configuration{
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"*.go"},
+				},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConfiguration_ValidateErr(t *testing.T) {
+	c := &configuration.Configuration{}
+	err := c.ValidateErr()
+	if !errors.Is(err, configuration.ErrMissingTask) || !errors.Is(err, configuration.ErrMissingFile) || !errors.Is(err, configuration.ErrMissing) {
+		t.Errorf("Expecting missing task and file errors, got %v", err)
+	}
+	if errors.Is(err, configuration.ErrUnknownTaskRef) {
+		t.Errorf("Expecting no unknown task reference, got %v", err)
+	}
+	if errs, ok := err.(configuration.Errors); !ok || len(errs) != len(c.Validate()) {
+		t.Errorf("Expecting Errors holding every validation error, got %T", err)
+	}
+	c = validConfiguration()
+	c.Script = append(c.Script, &configuration.Script{
+		Name: "lorem",
+		Task: []string{"ipsum"},
+	})
+	err = c.ValidateErr()
+	if !errors.Is(err, configuration.ErrUnknownTaskRef) || errors.Is(err, configuration.ErrMissingTask) {
+		t.Errorf("Expecting unknown task reference, got %v", err)
+	}
+	var e *configuration.ValidationError
+	if !errors.As(err, &e) || e.Code != configuration.CodeUnknown {
+		t.Errorf("Expecting ValidationError, got %v", e)
+	}
+	if err = validConfiguration().ValidateErr(); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+}
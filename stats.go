@@ -0,0 +1,99 @@
+package configuration
+
+// Stats contains counts and complexity metrics of Configuration, used by dashboards and complexity linting
+type Stats struct {
+	Task        int `json:"task"`
+	Script      int `json:"script"`
+	File        int `json:"file"`
+	FileType    int `json:"fileType"`
+	Plugin      int `json:"plugin"`
+	Regex       int `json:"regex"`
+	Definition  int `json:"definition"`
+	MaxInclude  int `json:"maxInclude"`
+	MaxExclude  int `json:"maxExclude"`
+	MaxTask     int `json:"maxTask"`
+	MatchedFile int `json:"matchedFile,omitempty"`
+}
+
+// Stats returns counts and metrics computed from the definitions alone; tasks are measured with Extends resolved
+func (c *Configuration) Stats() *Stats {
+	if c == nil {
+		return &Stats{}
+	}
+	stats := &Stats{
+		Task:   len(c.Task),
+		Script: len(c.Script),
+		File:   len(c.File),
+	}
+	if c.Definitions != nil {
+		stats.Definition = len(c.Definitions.Task) + len(c.Definitions.File)
+	}
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		t = c.resolve(t)
+		if t.Path == nil {
+			continue
+		}
+		if len(t.Path.Include) > stats.MaxInclude {
+			stats.MaxInclude = len(t.Path.Include)
+		}
+		if len(t.Path.Exclude) > stats.MaxExclude {
+			stats.MaxExclude = len(t.Path.Exclude)
+		}
+	}
+	for _, s := range c.Script {
+		if s == nil {
+			continue
+		}
+		if len(s.Task) > stats.MaxTask {
+			stats.MaxTask = len(s.Task)
+		}
+	}
+	var types []string
+	for _, f := range c.File {
+		if f == nil {
+			continue
+		}
+		f = c.resolveFile(f)
+		for _, t := range f.Type {
+			if !contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		if f.Modify != nil {
+			stats.Plugin += len(f.Modify.Plugin)
+			stats.Regex += len(f.Modify.Regex)
+		}
+	}
+	stats.FileType = len(types)
+	return stats
+}
+
+// StatsRoot returns Stats including the number of distinct files under root matched by any task
+func (c *Configuration) StatsRoot(root string) (*Stats, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	stats := c.Stats()
+	matched := map[string]bool{}
+	for _, t := range c.Task {
+		if t == nil {
+			continue
+		}
+		t = c.resolve(t)
+		if t.Path == nil {
+			continue
+		}
+		files, err := resolveFiles(root, t.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			matched[file] = true
+		}
+	}
+	stats.MatchedFile = len(matched)
+	return stats, nil
+}
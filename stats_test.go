@@ -0,0 +1,33 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_Stats(t *testing.T) {
+	root := t.TempDir()
+	c := planConfiguration(root)
+	stats := c.Stats()
+	expected := configuration.Stats{
+		Task:       2,
+		Script:     1,
+		File:       2,
+		FileType:   2,
+		Plugin:     1,
+		MaxInclude: 1,
+		MaxExclude: 1,
+		MaxTask:    2,
+	}
+	if *stats != expected {
+		t.Errorf("Expecting %v, got %v", expected, *stats)
+	}
+	stats, err := c.StatsRoot(root)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if stats.MatchedFile != 6 {
+		t.Errorf("Expecting 6 matched files, got %v", stats.MatchedFile)
+	}
+}
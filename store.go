@@ -0,0 +1,75 @@
+package configuration
+
+import (
+	"context"
+	"sync"
+)
+
+// Store holds a Configuration shared between goroutines, such as a watcher and its consumers; every access goes through its read-write mutex and consumers only ever see copies. NewStore, Reload and Watch only hold a Configuration that loads and passes ValidateErr with the options of the Store; one failing either keeps the previous Configuration
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	options []Option
+	current *Configuration
+}
+
+// NewStore returns a Store holding the file at path, or the file Load would select within a directory path, loaded and validated with the options
+func NewStore(path string, options ...Option) (*Store, error) {
+	s := &Store{
+		path:    path,
+		options: options,
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns a copy of the held Configuration; changes to the copy are not seen by the Store, use Update instead
+func (s *Store) Get() *Configuration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current.Clone()
+}
+
+// Update calls fn with a copy of the held Configuration and holds the copy once fn returns nil; readers see either the previous or the updated Configuration, never a partial update
+func (s *Store) Update(fn func(*Configuration) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	updated := s.current.Clone()
+	if err := fn(updated); err != nil {
+		return err
+	}
+	s.current = updated
+	return nil
+}
+
+// Reload loads and validates the file of the Store again and holds the result; the held Configuration is kept when the load or validation fails
+func (s *Store) Reload() error {
+	c := &Configuration{}
+	if err := c.LoadFrom(s.path, s.options...); err != nil {
+		return err
+	}
+	if err := c.ValidateErr(s.options...); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = c
+	return nil
+}
+
+// Watch reloads the file of the Store on every change until ctx is done, like WatchPath; fn, when set, receives a copy of each held Configuration, and a Configuration failing to load or validate is passed to it but never held
+func (s *Store) Watch(ctx context.Context, fn func(*Configuration, error)) error {
+	return WatchPath(ctx, s.path, func(c *Configuration, err error) {
+		if err == nil {
+			s.mu.Lock()
+			s.current = c
+			s.mu.Unlock()
+			c = c.Clone()
+		}
+		if fn != nil {
+			fn(c, err)
+		}
+	}, s.options...)
+}
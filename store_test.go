@@ -0,0 +1,118 @@
+package configuration_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	c := validConfiguration()
+	c.Name = "lorem"
+	if err := c.WriteToPath(path); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	s, err := configuration.NewStore(dir)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	c = s.Get()
+	c.Name = "changed"
+	if s.Get().Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v", s.Get().Name)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Update(func(c *configuration.Configuration) error {
+				c.Task = append(c.Task, &configuration.Task{Name: "docs"})
+				return nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			s.Get().Validate()
+		}()
+	}
+	wg.Wait()
+	if tasks := len(s.Get().Task); tasks != 21 {
+		t.Errorf("Expecting 21 tasks, got %v", tasks)
+	}
+	failed := errors.New("failed")
+	if err := s.Update(func(c *configuration.Configuration) error {
+		c.Name = "ipsum"
+		return failed
+	}); err != failed || s.Get().Name != "lorem" {
+		t.Errorf("Expecting failed update to be discarded, got %v %v", err, s.Get().Name)
+	}
+	c = validConfiguration()
+	c.Name = "ipsum"
+	if err := c.WriteToPath(path); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if err := s.Reload(); err != nil || s.Get().Name != "ipsum" || len(s.Get().Task) != 1 {
+		t.Errorf("Expecting reloaded ipsum, got %v %v", err, s.Get())
+	}
+	os.WriteFile(path, []byte(`{"name": "invalid"}`), 0644)
+	if err := s.Reload(); err == nil || s.Get().Name != "ipsum" {
+		t.Errorf("Expecting validation error keeping ipsum, got %v %v", err, s.Get().Name)
+	}
+	if _, err := configuration.NewStore(dir); err == nil {
+		t.Errorf("Expecting validation error, got nil")
+	}
+	os.WriteFile(path, []byte(`{"name": `), 0644)
+	if err := s.Reload(); err == nil || s.Get().Name != "ipsum" {
+		t.Errorf("Expecting error keeping ipsum, got %v %v", err, s.Get().Name)
+	}
+	if _, err := configuration.NewStore(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}
+
+func TestStore_Watch(t *testing.T) {
+	dir := t.TempDir()
+	if err := validConfiguration().WriteToPath(filepath.Join(dir, "emits.json")); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	s, err := configuration.NewStore(dir)
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	name := s.Get().Name
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	defer func() {
+		cancel()
+		<-done
+	}()
+	watched := make(chan error, 10)
+	go func() {
+		done <- s.Watch(ctx, func(c *configuration.Configuration, err error) {
+			if c != nil {
+				c.Name = "changed"
+			}
+			watched <- err
+		})
+	}()
+	select {
+	case err := <-watched:
+		if err != nil {
+			t.Fatalf("Expecting nil, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expecting load, got none")
+	}
+	if s.Get().Name != name {
+		t.Errorf("Expecting %v held unchanged, got %v", name, s.Get().Name)
+	}
+}
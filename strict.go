@@ -0,0 +1,70 @@
+package configuration
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Errors contains several errors returned as a single error
+type Errors []error
+
+// Error returns every error message joined
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns every error so errors.Is and errors.As match any of them
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// UnknownFields returns a ValidationError for every key of the document that does not map to a Configuration field, with the closest known keys suggested
+func UnknownFields(document []byte, format Format) ([]error, error) {
+	if format == JSONC {
+		document, format = StripJSONC(document), JSON
+	}
+	node, err := decodeNode(document, format)
+	if err != nil {
+		return nil, err
+	}
+	var errors []error
+	unknownFields(node, reflect.TypeOf(Configuration{}), "", &errors)
+	return errors, nil
+}
+
+// unknownFields appends an error for every mapping key below node not declared by the Go type t
+func unknownFields(node *yaml.Node, t reflect.Type, p string, errors *[]error) {
+	t = indirect(t)
+	switch node.Kind {
+	case yaml.MappingNode:
+		switch t.Kind() {
+		case reflect.Struct:
+			names, fields := jsonFields(t)
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				field, ok := fields[key]
+				if !ok {
+					*errors = append(*errors, newError(CodeUnknown, p+pointer(key), "unknown field `%s`", key).suggest(key, names))
+					continue
+				}
+				unknownFields(node.Content[i+1], field.Type, p+pointer(key), errors)
+			}
+		case reflect.Map:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				unknownFields(node.Content[i+1], t.Elem(), p+pointer(node.Content[i].Value), errors)
+			}
+		}
+	case yaml.SequenceNode:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, child := range node.Content {
+				unknownFields(child, t.Elem(), p+pointer(i), errors)
+			}
+		}
+	}
+}
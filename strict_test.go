@@ -0,0 +1,32 @@
+package configuration_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_LoadStrict(t *testing.T) {
+	document := `{"name":"lorem","taks":[],"task":[{"name":"ipsum","path":{"inclde":["*"]}}]}`
+	c := &configuration.Configuration{}
+	if err := c.LoadReader(strings.NewReader(document)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	err := c.LoadReader(strings.NewReader(document), configuration.WithStrict())
+	var errs configuration.Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Expecting 2 errors, got %v", err)
+	}
+	if configuration.Pointer(errs[0]) != "/taks" || configuration.Pointer(errs[1]) != "/task/0/path/inclde" {
+		t.Errorf("Expecting /taks and /task/0/path/inclde, got %v %v", configuration.Pointer(errs[0]), configuration.Pointer(errs[1]))
+	}
+	if !strings.Contains(errs[1].Error(), "did you mean `include`") {
+		t.Errorf("Expecting include suggestion, got %v", errs[1])
+	}
+	c = &configuration.Configuration{}
+	if err := c.LoadReader(strings.NewReader(`{"name":"lorem"}`), configuration.WithStrict()); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+}
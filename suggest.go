@@ -0,0 +1,82 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxSuggestions constant for the most candidates returned by Suggestions
+	MaxSuggestions = 3
+)
+
+// Suggestions returns the known names closest to name by case-insensitive edit distance, nearest first; names too distant to be a likely typo are never returned
+func Suggestions(name string, known []string) []string {
+	var candidates []string
+	distances := map[string]int{}
+	for _, candidate := range known {
+		if candidate == name {
+			continue
+		}
+		if _, ok := distances[candidate]; ok {
+			continue
+		}
+		d := distance(strings.ToLower(name), strings.ToLower(candidate))
+		if d > len([]rune(name))/3+1 {
+			continue
+		}
+		distances[candidate] = d
+		candidates = append(candidates, candidate)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distances[candidates[i]] < distances[candidates[j]]
+	})
+	if len(candidates) > MaxSuggestions {
+		candidates = candidates[:MaxSuggestions]
+	}
+	return candidates
+}
+
+// suggest returns the ValidationError with the Suggestions for name appended to the message, and a Fix replacing the value at its pointer for each
+func (e *ValidationError) suggest(name string, known []string) *ValidationError {
+	e.Suggestion = Suggestions(name, known)
+	if len(e.Suggestion) == 0 {
+		return e
+	}
+	e.Format += "; did you mean `%s`?"
+	e.Args = append(e.Args, strings.Join(e.Suggestion, "`, `"))
+	for _, suggestion := range e.Suggestion {
+		e.replace(fmt.Sprintf("Replace with `%s`", suggestion), e.Pointer, suggestion)
+	}
+	return e
+}
+
+// distance returns the Levenshtein edit distance between a and b
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		previous := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			current := row[j]
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			row[j] = previous + cost
+			if row[j-1]+1 < row[j] {
+				row[j] = row[j-1] + 1
+			}
+			if current+1 < row[j] {
+				row[j] = current + 1
+			}
+			previous = current
+		}
+	}
+	return row[len(rb)]
+}
@@ -0,0 +1,36 @@
+package configuration_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestSuggestions(t *testing.T) {
+	s := configuration.Suggestions("lorme", []string{"ipsum", "lorem", "Lorem", "loremipsum"})
+	if len(s) != 2 || s[0] != "lorem" || s[1] != "Lorem" {
+		t.Errorf("Expecting lorem and Lorem, got %v", s)
+	}
+	s = configuration.Suggestions("x", []string{"lorem"})
+	if s != nil {
+		t.Errorf("Expecting nil, got %v", s)
+	}
+}
+
+func TestScript_ValidateSuggestion(t *testing.T) {
+	c := paramConfiguration()
+	c.Script[0].Task = []string{"bsae", "docs"}
+	errs := c.Script[0].Validate(c)
+	if len(errs) == 0 {
+		t.Fatalf("Expecting errors, got %v", errs)
+	}
+	var e *configuration.ValidationError
+	if !errors.As(errs[0], &e) || len(e.Suggestion) != 1 || e.Suggestion[0] != "base" {
+		t.Errorf("Expecting base suggestion, got %v", errs[0])
+	}
+	if !strings.Contains(e.Error(), "did you mean `base`?") {
+		t.Errorf("Expecting did you mean in message, got %v", e.Error())
+	}
+}
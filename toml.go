@@ -0,0 +1,40 @@
+package configuration
+
+import (
+	"encoding/json"
+)
+
+func init() {
+	RegisterCodec(tomlCodec{})
+}
+
+// tomlCodec is the built-in Codec of `emits.toml`; documents are converted through JSON so the `json` struct tags apply
+type tomlCodec struct{}
+
+// Name returns the TOML Format
+func (tomlCodec) Name() string {
+	return string(TOML)
+}
+
+// Extensions returns the TOML file extensions
+func (tomlCodec) Extensions() []string {
+	return []string{"toml"}
+}
+
+// Marshal returns v encoded as TOML, keeping the field order of JSON encoding; empty values are omitted as TOML has no null
+func (tomlCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Convert(data, JSON, TOML)
+}
+
+// Unmarshal decodes TOML data into v
+func (tomlCodec) Unmarshal(data []byte, v interface{}) error {
+	data, err := Convert(data, TOML, JSON)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
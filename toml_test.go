@@ -0,0 +1,67 @@
+package configuration_test
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_WriteTOML(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	c := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{
+				Name: "ipsum",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+					Exclude: []string{"vendor/**"},
+				},
+			},
+		},
+		Script: []*configuration.Script{
+			{
+				Name: "build",
+				Task: []string{"ipsum"},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+						Block: &core.CommentBlock{
+							Start: "/*",
+							End:   "*/",
+						},
+					},
+					Source: true,
+				},
+			},
+		},
+	}
+	if err := c.Write(configuration.WithFormat(configuration.TOML), configuration.WithVerify()); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	data, err := os.ReadFile("emits.toml")
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.Contains(string(data), "[[task]]") {
+		t.Errorf("Expecting toml array of tables, got %s", data)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !reflect.DeepEqual(c, loaded) {
+		t.Errorf("Expecting round trip, got %s", data)
+	}
+}
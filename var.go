@@ -0,0 +1,142 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// varReference matches a `{{var.name}}` reference within a string value
+var varReference = regexp.MustCompile(`\{\{\s*var\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// varFields contains the pointer patterns of every string that may reference a var; project paths and file types
+var varFields = append([]string{"**/type/*"}, projectFields...)
+
+// visitVarFields calls visit with the JSON pointer and settable value of every string that may reference a var, except within Projects which resolve their own vars
+func (c *Configuration) visitVarFields(visit func(p string, value reflect.Value)) {
+	visitStrings(reflect.ValueOf(c), "", func(p string, value reflect.Value) {
+		if strings.HasPrefix(p, "/projects/") {
+			return
+		}
+		for _, field := range varFields {
+			if match(field, strings.TrimPrefix(p, "/")) {
+				visit(p, value)
+				return
+			}
+		}
+	})
+}
+
+// Vars returns every var with its references to other vars resolved; returns an error on unknown or cyclic references
+func (c *Configuration) Vars() (map[string]string, error) {
+	if c == nil {
+		return nil, nullConfiguration()
+	}
+	resolved := map[string]string{}
+	names := make([]string, 0, len(c.Var))
+	for name := range c.Var {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := c.resolveVar(name, nil, resolved); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
+
+// resolveVar returns the value of the named var with its references resolved into resolved; chain tracks visited names for cycle detection
+func (c *Configuration) resolveVar(name string, chain []string, resolved map[string]string) (string, error) {
+	if value, ok := resolved[name]; ok {
+		return value, nil
+	}
+	if contains(chain, name) {
+		return "", fmt.Errorf("`%s` var cycle `%s`", chain[0], strings.Join(append(chain, name), " > "))
+	}
+	value, ok := c.Var[name]
+	if !ok {
+		if len(chain) == 0 {
+			return "", fmt.Errorf("unknown `%s` var", name)
+		}
+		return "", fmt.Errorf("`%s` var referencing unknown `%s` var", chain[len(chain)-1], name)
+	}
+	chain = append(chain, name)
+	var err error
+	value = varReference.ReplaceAllStringFunc(value, func(reference string) string {
+		if err != nil {
+			return reference
+		}
+		var v string
+		v, err = c.resolveVar(varReference.FindStringSubmatch(reference)[1], chain, resolved)
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	resolved[name] = value
+	return value, nil
+}
+
+// ValidateVars returns an error for every cyclic var and every reference to an unknown var
+func (c *Configuration) ValidateVars() []error {
+	if c == nil {
+		return []error{nullConfiguration()}
+	}
+	var errors []error
+	resolved := map[string]string{}
+	names := make([]string, 0, len(c.Var))
+	for name := range c.Var {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := c.resolveVar(name, nil, resolved); err != nil {
+			errors = append(errors, wrapError(CodeInvalid, pointer("var", name), err))
+		}
+	}
+	c.visitVarFields(func(p string, value reflect.Value) {
+		for _, match := range varReference.FindAllStringSubmatch(value.String(), -1) {
+			if _, ok := c.Var[match[1]]; !ok {
+				errors = append(errors, newError(CodeUnknown, p, "unknown `%s` var", match[1]).suggest(match[1], names))
+			}
+		}
+	})
+	return errors
+}
+
+// ResolveVars replaces every `{{var.name}}` reference within project paths and file types with the resolved var, within Projects with their own vars over those of Configuration; returns an error on unknown or cyclic references
+func (c *Configuration) ResolveVars() error {
+	if c == nil {
+		return nullConfiguration()
+	}
+	vars, err := c.Vars()
+	if err != nil {
+		return err
+	}
+	c.visitVarFields(func(p string, value reflect.Value) {
+		if err != nil {
+			return
+		}
+		value.SetString(varReference.ReplaceAllStringFunc(value.String(), func(reference string) string {
+			name := varReference.FindStringSubmatch(reference)[1]
+			v, ok := vars[name]
+			if !ok {
+				err = newError(CodeUnknown, p, "unknown `%s` var", name)
+				return reference
+			}
+			return v
+		}))
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range c.ProjectNames() {
+		if err := c.scoped(name).ResolveVars(); err != nil {
+			return fmt.Errorf("`%s` project: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package configuration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestConfiguration_ResolveVars(t *testing.T) {
+	document := `{
+		"var": {"src": "{{var.root}}/src", "root": "project", "lang": "go"},
+		"task": [{"name": "lorem", "path": {"include": ["{{var.src}}/**/*.{{ var.lang }}"]}}],
+		"file": [{"type": ["{{var.lang}}"], "modify": {"plugin": [{"path": "{{var.root}}/plugin.js"}]}}]
+	}`
+	c := &configuration.Configuration{}
+	if err := c.LoadReader(strings.NewReader(document)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if include := c.Task[0].Path.Include[0]; include != "project/src/**/*.go" {
+		t.Errorf("Expecting project/src/**/*.go, got %v", include)
+	}
+	if c.File[0].Type[0] != "go" || c.File[0].Modify.Plugin[0].Path != "project/plugin.js" {
+		t.Errorf("Expecting go and project/plugin.js, got %v %v", c.File[0].Type, c.File[0].Modify.Plugin[0].Path)
+	}
+	err := c.LoadReader(strings.NewReader(`{"var": {"a": "{{var.b}}", "b": "{{var.a}}"}}`))
+	if err == nil || !strings.Contains(err.Error(), "cycle `a > b > a`") {
+		t.Errorf("Expecting cycle, got %v", err)
+	}
+}
+
+func TestConfiguration_ValidateVars(t *testing.T) {
+	c := &configuration.Configuration{
+		Var: map[string]string{
+			"source": "src",
+		},
+		Task: []*configuration.Task{
+			{
+				Name: "lorem",
+				Path: &configuration.Path{
+					Include: []string{"{{var.sources}}/*"},
+				},
+			},
+		},
+	}
+	errs := c.ValidateVars()
+	if len(errs) != 1 || configuration.Pointer(errs[0]) != "/task/0/path/include/0" || !strings.Contains(errs[0].Error(), "did you mean `source`?") {
+		t.Errorf("Expecting unknown var with suggestion, got %v", errs)
+	}
+	if err := c.ResolveVars(); err == nil || c.Task[0].Path.Include[0] != "{{var.sources}}/*" {
+		t.Errorf("Expecting error and unchanged include, got %v %v", err, c.Task[0].Path.Include)
+	}
+}
@@ -0,0 +1,47 @@
+package configuration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+)
+
+// verify returns an error unless the file at path holds exactly the written data, decodes and re-encodes to the same data, and validates as Configuration does
+func (c *Configuration) verify(path string, codec Codec, data []byte) error {
+	persisted, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not verify `%s`: %v", path, err)
+	}
+	if sha256.Sum256(persisted) != sha256.Sum256(data) {
+		return fmt.Errorf("could not verify `%s`: persisted content differs from written content", path)
+	}
+	reloaded := &Configuration{}
+	if err := codec.Unmarshal(persisted, reloaded); err != nil {
+		return fmt.Errorf("could not verify `%s`: %v", path, err)
+	}
+	encoded, err := codec.Marshal(reloaded)
+	if err != nil {
+		return fmt.Errorf("could not verify `%s`: %v", path, err)
+	}
+	if !bytes.Equal(encoded, data) {
+		return fmt.Errorf("could not verify `%s`: content does not round-trip through the %s codec", path, codec.Name())
+	}
+	if !sameErrors(c.Clone().Validate(), reloaded.Validate()) {
+		return fmt.Errorf("could not verify `%s`: validation differs after reading the file back", path)
+	}
+	return nil
+}
+
+// sameErrors returns true if both lists hold errors of the same Code at the same JSON pointer in the same order; messages may name generated values such as the placeholder name of a nameless task
+func sameErrors(a, b []error) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if ErrorCode(a[i]) != ErrorCode(b[i]) || Pointer(a[i]) != Pointer(b[i]) {
+			return false
+		}
+	}
+	return true
+}
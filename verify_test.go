@@ -0,0 +1,65 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+// lossyCodec is a Codec that drops the Configuration description when decoding
+type lossyCodec struct{}
+
+func (lossyCodec) Name() string {
+	return "lossy"
+}
+
+func (lossyCodec) Extensions() []string {
+	return []string{"lossy"}
+}
+
+func (lossyCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (lossyCodec) Unmarshal(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if c, ok := v.(*configuration.Configuration); ok {
+		c.Description = ""
+	}
+	return err
+}
+
+func TestConfiguration_WriteVerify(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	configuration.RegisterCodec(lossyCodec{})
+	c := &configuration.Configuration{
+		Name:        "lorem",
+		Description: "ipsum",
+	}
+	if err := c.Write(configuration.WithVerify()); err != nil {
+		t.Errorf("Expecting nil, got %v", err)
+	}
+	err := c.Write(configuration.WithFormat("lossy"), configuration.WithVerify())
+	if err == nil || !strings.Contains(err.Error(), "round-trip") {
+		t.Errorf("Expecting round-trip error, got %v", err)
+	}
+	os.Remove("emits.lossy")
+	c = &configuration.Configuration{
+		Name:   "lorem",
+		Task:   []*configuration.Task{{Path: &configuration.Path{Include: []string{"*.go"}}}},
+		Script: []*configuration.Script{{Task: []string{"lorem"}}},
+		File:   []*configuration.File{{Parse: &configuration.Parse{Comment: &core.Comment{Line: "//"}}}},
+	}
+	if err := c.Write(configuration.WithVerify()); err != nil {
+		t.Errorf("Expecting nil for nameless definitions, got %v", err)
+	}
+	if c.Task[0].Name != "" || c.Script[0].Name != "" || c.File[0].Type != nil {
+		t.Errorf("Expecting Configuration unchanged, got %v %v %v", c.Task[0].Name, c.Script[0].Name, c.File[0].Type)
+	}
+}
@@ -0,0 +1,177 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// Symlinks identifies how a Path treats symbolic links to files and directories
+type Symlinks string
+
+const (
+	// SymlinkFollow constant for symbolic links resolved to their target, descending into linked directories; a link to a directory being walked is a loop
+	SymlinkFollow Symlinks = "follow"
+	// SymlinkSkip constant for symbolic links left out of every file list
+	SymlinkSkip Symlinks = "skip"
+	// SymlinkError constant for symbolic links failing the file list
+	SymlinkError Symlinks = "error"
+)
+
+var (
+	// ErrSymlink is the error class of a symbolic link found by a Path with SymlinkError
+	ErrSymlink = errors.New("symbolic link not allowed")
+	// ErrSymlinkLoop is the error class of a symbolic link to a directory being walked by a Path with SymlinkFollow
+	ErrSymlinkLoop = errors.New("symbolic link loop")
+)
+
+// valid returns true for an empty policy, which lists symbolic links as files without following them, and for every Symlinks constant
+func (s Symlinks) valid() bool {
+	switch s {
+	case "", SymlinkFollow, SymlinkSkip, SymlinkError:
+		return true
+	}
+	return false
+}
+
+// walker decides which entries of a file system walk a Path matches, keeping the ignore rules of the directories walked so far
+type walker struct {
+	ctx     context.Context
+	fsys    fs.FS
+	path    *Path
+	include *globs
+	exclude *globs
+	ignore  []ignoreRule
+}
+
+// newWalker returns a walker of the file system for the Path, stopping with the error of ctx once it is done
+func newWalker(ctx context.Context, fsys fs.FS, p *Path) *walker {
+	if p == nil {
+		p = &Path{}
+	}
+	return &walker{
+		ctx:     ctx,
+		fsys:    fsys,
+		path:    p,
+		include: compileGlobs(p.Include, true),
+		exclude: compileGlobs(p.Exclude, false),
+	}
+}
+
+// walk calls fn for every entry of the file system as fs.WalkDir does, treating symbolic links as the Symlinks of the Path directs
+func (w *walker) walk(fn fs.WalkDirFunc) error {
+	info, err := fs.Stat(w.fsys, ".")
+	if err != nil {
+		err = fn(".", nil, err)
+	} else {
+		err = w.walkDir(".", fs.FileInfoToDirEntry(info), nil, fn)
+	}
+	if err == fs.SkipDir || err == fs.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkDir calls fn for the entry and, for a directory, every entry below it, until the context of the walker is done; ancestors holds the directories being walked for loop detection
+func (w *walker) walkDir(name string, d fs.DirEntry, ancestors []fs.FileInfo, fn fs.WalkDirFunc) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	if err := fn(name, d, nil); err != nil || !d.IsDir() {
+		if err == fs.SkipDir && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+	entries, err := fs.ReadDir(w.fsys, name)
+	if err != nil {
+		if err = fn(name, d, err); err != nil {
+			if err == fs.SkipDir {
+				err = nil
+			}
+			return err
+		}
+	}
+	if info, err := fs.Stat(w.fsys, name); err == nil {
+		ancestors = append(ancestors, info)
+	}
+	for _, entry := range entries {
+		child := path.Join(name, entry.Name())
+		if entry.Type()&fs.ModeSymlink != 0 {
+			target, err := w.symlink(child, entry, ancestors)
+			if err != nil {
+				if err = fn(child, entry, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if target == nil {
+				continue
+			}
+			entry = target
+		}
+		if err := w.walkDir(child, entry, ancestors, fn); err != nil {
+			if err == fs.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// symlink returns the entry the symbolic link is walked as, the entry of its target when followed, or nil if the link is skipped; an error is returned for a link the Path does not allow or a loop
+func (w *walker) symlink(name string, entry fs.DirEntry, ancestors []fs.FileInfo) (fs.DirEntry, error) {
+	switch w.path.Symlinks {
+	case SymlinkSkip:
+		return nil, nil
+	case SymlinkError:
+		return nil, &fs.PathError{Op: "walk", Path: name, Err: ErrSymlink}
+	case SymlinkFollow:
+	default:
+		return entry, nil
+	}
+	info, err := fs.Stat(w.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		for _, ancestor := range ancestors {
+			if os.SameFile(ancestor, info) {
+				return nil, &fs.PathError{Op: "walk", Path: name, Err: ErrSymlinkLoop}
+			}
+		}
+	}
+	return fs.FileInfoToDirEntry(info), nil
+}
+
+// hidden returns true if the Path skips the walked entry for its depth or for being hidden
+func (w *walker) hidden(relative string, dir bool) bool {
+	if relative == "." {
+		return false
+	}
+	if depth := strings.Count(relative, "/") + 1; w.path.MaxDepth > 0 && (depth > w.path.MaxDepth || dir && depth == w.path.MaxDepth) {
+		return true
+	}
+	return w.path.Hidden != nil && !*w.path.Hidden && strings.HasPrefix(path.Base(relative), ".")
+}
+
+// visit returns true if the walked entry is a file the Path matches; fs.SkipDir is returned for a directory that is excluded, ignored, hidden or at MaxDepth
+func (w *walker) visit(relative string, d fs.DirEntry) (bool, error) {
+	if d.IsDir() {
+		if relative != "." && (w.hidden(relative, true) || w.exclude.any(relative) || w.path.Gitignore && (d.Name() == ".git" || ignored(w.ignore, relative, true))) {
+			return false, fs.SkipDir
+		}
+		if w.path.Gitignore {
+			w.ignore = append(w.ignore, loadIgnore(w.fsys, relative)...)
+		}
+		return false, nil
+	}
+	if w.hidden(relative, false) || w.path.Gitignore && ignored(w.ignore, relative, false) {
+		return false, nil
+	}
+	return w.include.included(relative) && !w.exclude.any(relative), nil
+}
@@ -0,0 +1,93 @@
+package configuration_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestPath_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "src"), 0755)
+	os.MkdirAll(filepath.Join(root, "shared"), 0755)
+	os.WriteFile(filepath.Join(root, "src", "main.go"), nil, 0644)
+	os.WriteFile(filepath.Join(root, "shared", "util.go"), nil, 0644)
+	if err := os.Symlink(filepath.Join(root, "shared"), filepath.Join(root, "src", "shared")); err != nil {
+		t.Skipf("Expecting symbolic links, got %v", err)
+	}
+	os.Symlink(filepath.Join(root, "shared", "util.go"), filepath.Join(root, "src", "link.go"))
+	task := &configuration.Task{
+		Name: "src",
+		Path: &configuration.Path{
+			Include: []string{"src/**/*.go"},
+		},
+	}
+	files, err := task.Files(root)
+	if expecting := []string{"src/link.go", "src/main.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	task.Path.Symlinks = configuration.SymlinkSkip
+	files, err = task.Files(root)
+	if expecting := []string{"src/main.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	task.Path.Symlinks = configuration.SymlinkFollow
+	files, err = task.Files(root)
+	if expecting := []string{"src/link.go", "src/main.go", "src/shared/util.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	task.Path.Symlinks = configuration.SymlinkError
+	if _, err := task.Files(root); !errors.Is(err, configuration.ErrSymlink) {
+		t.Errorf("Expecting ErrSymlink, got %v", err)
+	}
+	os.Symlink(root, filepath.Join(root, "shared", "root"))
+	task.Path.Symlinks = configuration.SymlinkFollow
+	if _, err := task.Files(root); !errors.Is(err, configuration.ErrSymlinkLoop) {
+		t.Errorf("Expecting ErrSymlinkLoop, got %v", err)
+	}
+	task.Path.Symlinks = "always"
+	if errs := task.Validate(); len(errs) != 1 || configuration.Pointer(errs[0]) != "/path/symlinks" {
+		t.Errorf("Expecting invalid symlinks, got %v", errs)
+	}
+}
+
+func TestPath_MaxDepthHidden(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"main.go", ".hidden.go", "a/a.go", "a/b/b.go", ".config/c.go"} {
+		os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0755)
+		os.WriteFile(filepath.Join(root, name), nil, 0644)
+	}
+	task := &configuration.Task{
+		Name: "go",
+		Path: &configuration.Path{
+			Include:  []string{"**/*.go"},
+			MaxDepth: 2,
+		},
+	}
+	files, err := task.Files(root)
+	if expecting := []string{".config/c.go", ".hidden.go", "a/a.go", "main.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	hidden := false
+	task.Path.Hidden = &hidden
+	files, err = task.Files(root)
+	if expecting := []string{"a/a.go", "main.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	task.Path.MaxDepth = 1
+	files, err = task.Files(root)
+	if expecting := []string{"main.go"}; err != nil || !reflect.DeepEqual(files, expecting) {
+		t.Errorf("Expecting %v, got %v %v", expecting, files, err)
+	}
+	if clone := task.Clone(); clone.Path.Hidden == task.Path.Hidden || *clone.Path.Hidden {
+		t.Errorf("Expecting a copy of hidden, got %v", clone.Path.Hidden)
+	}
+	task.Path.MaxDepth = -1
+	if errs := task.Validate(); len(errs) != 1 || configuration.Pointer(errs[0]) != "/path/maxDepth" {
+		t.Errorf("Expecting invalid maxDepth, got %v", errs)
+	}
+}
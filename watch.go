@@ -0,0 +1,107 @@
+package configuration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchDelay is how long Watch waits after the last change of the configuration file before reloading it, so an editor saving in several steps causes a single reload
+var WatchDelay = 100 * time.Millisecond
+
+// Watch loads the configuration file Load would select and reloads it on every change; see WatchPath
+func Watch(ctx context.Context, fn func(*Configuration, error), options ...Option) error {
+	return WatchPath(ctx, ".", fn, options...)
+}
+
+// WatchPath loads the file at path, or the file Load would select within a directory path, then reloads and revalidates it on every change to it, to a local file it includes or extends or to its ConfigDir fragments until ctx is done, returning its error; fn receives each Configuration with its validation errors joined, or nil and the error of a failed load or of the watcher
+func WatchPath(ctx context.Context, path string, fn func(*Configuration, error), options ...Option) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	dir, name := path, ""
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		dir, name = filepath.Dir(path), filepath.Base(path)
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		return err
+	}
+	dropIns := filepath.Join(dir, ConfigDir)
+	watching := map[string]bool{dir: true}
+	sources := map[string]bool{}
+	reload := func() {
+		var loaded []string
+		c := &Configuration{}
+		err := c.LoadFrom(path, append(options[:len(options):len(options)], withSources(&loaded))...)
+		sources = map[string]bool{}
+		for _, source := range append(loaded, dropIns) {
+			if absolute, err := filepath.Abs(source); err == nil {
+				sources[absolute] = true
+				if parent := filepath.Dir(absolute); !watching[parent] && watcher.Add(parent) == nil {
+					watching[parent] = true
+				}
+			}
+		}
+		if !watching[dropIns] && watcher.Add(dropIns) == nil {
+			watching[dropIns] = true
+		}
+		if err != nil {
+			fn(nil, err)
+			return
+		}
+		fn(c, c.ValidateErr(options...))
+	}
+	reload()
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return ctx.Err()
+			}
+			if event.Op != fsnotify.Chmod && (filepath.Dir(event.Name) == dir && watched(filepath.Base(event.Name), name) || sources[event.Name] || filepath.Dir(event.Name) == dropIns && filepath.Ext(event.Name) == ".json") {
+				pending = time.After(WatchDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return ctx.Err()
+			}
+			fn(nil, err)
+		case <-pending:
+			pending = nil
+			reload()
+		}
+	}
+}
+
+// watched returns true if the changed file is the watched file name or, when no name is watched, a file Load may select: ConfigFile with the extension of a registered Codec or one of AlternateConfigFiles
+func watched(changed string, name string) bool {
+	if len(name) > 0 {
+		return changed == name
+	}
+	if contains(AlternateConfigFiles, changed) {
+		return true
+	}
+	extension := filepath.Ext(changed)
+	if strings.TrimSuffix(changed, extension) != strings.TrimSuffix(ConfigFile, filepath.Ext(ConfigFile)) {
+		return false
+	}
+	for _, codec := range Codecs() {
+		if contains(codec.Extensions(), strings.TrimPrefix(extension, ".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package configuration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/emits-io/configuration"
+)
+
+func TestWatchPath(t *testing.T) {
+	delay := configuration.WatchDelay
+	configuration.WatchDelay = 10 * time.Millisecond
+	defer func() {
+		configuration.WatchDelay = delay
+	}()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "emits.json")
+	os.WriteFile(path, []byte(`{"name": "lorem"}`), 0644)
+	ctx, cancel := context.WithCancel(context.Background())
+	type reload struct {
+		c   *configuration.Configuration
+		err error
+	}
+	reloads := make(chan reload, 10)
+	done := make(chan error)
+	go func() {
+		done <- configuration.WatchPath(ctx, dir, func(c *configuration.Configuration, err error) {
+			reloads <- reload{c, err}
+		})
+	}()
+	next := func() reload {
+		select {
+		case r := <-reloads:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expecting reload, got none")
+		}
+		return reload{}
+	}
+	if r := next(); r.c == nil || r.c.Name != "lorem" || r.err == nil {
+		t.Errorf("Expecting lorem with validation errors, got %v %v", r.c, r.err)
+	}
+	os.WriteFile(filepath.Join(dir, "readme.md"), []byte(""), 0644)
+	os.WriteFile(path, []byte(`{"name": "ipsum"}`), 0644)
+	if r := next(); r.c == nil || r.c.Name != "ipsum" {
+		t.Errorf("Expecting ipsum, got %v %v", r.c, r.err)
+	}
+	os.WriteFile(path, []byte(`{"name": `), 0644)
+	if r := next(); r.c != nil || r.err == nil {
+		t.Errorf("Expecting load error, got %v %v", r.c, r.err)
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expecting context.Canceled, got %v", err)
+	}
+	select {
+	case r := <-reloads:
+		t.Errorf("Expecting no further reload, got %v %v", r.c, r.err)
+	default:
+	}
+}
+
+func TestWatchPath_Alternate(t *testing.T) {
+	delay := configuration.WatchDelay
+	configuration.WatchDelay = 10 * time.Millisecond
+	defer func() {
+		configuration.WatchDelay = delay
+	}()
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".emitsrc")
+	os.WriteFile(path, []byte(`{"name": "lorem"}`), 0644)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	defer func() {
+		cancel()
+		<-done
+	}()
+	names := make(chan string, 10)
+	go func() {
+		done <- configuration.WatchPath(ctx, dir, func(c *configuration.Configuration, err error) {
+			if c != nil {
+				names <- c.Name
+			}
+		})
+	}()
+	for _, expecting := range []string{"lorem", "ipsum"} {
+		select {
+		case name := <-names:
+			if name != expecting {
+				t.Errorf("Expecting %v, got %v", expecting, name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expecting %v reload, got none", expecting)
+		}
+		os.WriteFile(path, []byte(`{"name": "ipsum"}`), 0644)
+	}
+}
+
+func TestWatchPath_Sources(t *testing.T) {
+	delay := configuration.WatchDelay
+	configuration.WatchDelay = 10 * time.Millisecond
+	defer func() {
+		configuration.WatchDelay = delay
+	}()
+	dir := t.TempDir()
+	project, shared := filepath.Join(dir, "project"), filepath.Join(dir, "shared")
+	os.Mkdir(project, 0755)
+	os.Mkdir(shared, 0755)
+	os.WriteFile(filepath.Join(project, "emits.json"), []byte(`{"extends": "../shared/base.json", "include": ["tasks.json"]}`), 0644)
+	os.WriteFile(filepath.Join(project, "tasks.json"), []byte(`{"task": [{"name": "lorem"}]}`), 0644)
+	os.WriteFile(filepath.Join(shared, "base.json"), []byte(`{"name": "lorem"}`), 0644)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	defer func() {
+		cancel()
+		<-done
+	}()
+	loads := make(chan *configuration.Configuration, 10)
+	go func() {
+		done <- configuration.WatchPath(ctx, project, func(c *configuration.Configuration, err error) {
+			if c != nil {
+				loads <- c
+			}
+		})
+	}()
+	next := func() *configuration.Configuration {
+		select {
+		case c := <-loads:
+			return c
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expecting reload, got none")
+		}
+		return nil
+	}
+	if c := next(); c.Name != "lorem" || len(c.Task) != 1 || c.Task[0].Name != "lorem" {
+		t.Fatalf("Expecting lorem, got %+v", c)
+	}
+	os.WriteFile(filepath.Join(shared, "base.json"), []byte(`{"name": "ipsum"}`), 0644)
+	if c := next(); c.Name != "ipsum" {
+		t.Errorf("Expecting ipsum from the extended base, got %v", c.Name)
+	}
+	os.WriteFile(filepath.Join(project, "tasks.json"), []byte(`{"task": [{"name": "ipsum"}]}`), 0644)
+	if c := next(); len(c.Task) != 1 || c.Task[0].Name != "ipsum" {
+		t.Errorf("Expecting ipsum task from the include, got %+v", c.Task)
+	}
+	os.Mkdir(filepath.Join(project, configuration.ConfigDir), 0755)
+	next()
+	os.WriteFile(filepath.Join(project, configuration.ConfigDir, "local.json"), []byte(`{"task": [{"name": "dolor"}]}`), 0644)
+	if c := next(); len(c.Task) != 2 {
+		t.Errorf("Expecting dolor task from the drop-in, got %+v", c.Task)
+	}
+}
@@ -0,0 +1,40 @@
+package configuration
+
+import (
+	"encoding/json"
+)
+
+func init() {
+	RegisterCodec(yamlCodec{})
+}
+
+// yamlCodec is the built-in Codec of `emits.yaml` and `emits.yml`; documents are converted through JSON so the `json` struct tags apply
+type yamlCodec struct{}
+
+// Name returns the YAML Format
+func (yamlCodec) Name() string {
+	return string(YAML)
+}
+
+// Extensions returns the YAML file extensions
+func (yamlCodec) Extensions() []string {
+	return []string{"yaml", "yml"}
+}
+
+// Marshal returns v encoded as YAML, keeping the field order of JSON encoding
+func (yamlCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Convert(data, JSON, YAML)
+}
+
+// Unmarshal decodes YAML data into v
+func (yamlCodec) Unmarshal(data []byte, v interface{}) error {
+	data, err := Convert(data, YAML, JSON)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
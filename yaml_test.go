@@ -0,0 +1,62 @@
+package configuration_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/configuration"
+	"github.com/emits-io/core"
+)
+
+func TestConfiguration_WriteYAML(t *testing.T) {
+	wd, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(wd)
+	c := &configuration.Configuration{
+		Name: "lorem",
+		Task: []*configuration.Task{
+			{
+				Name: "ipsum",
+				Path: &configuration.Path{
+					Include: []string{"**/*.go"},
+				},
+			},
+		},
+		File: []*configuration.File{
+			{
+				Type: []string{"go"},
+				Parse: &configuration.Parse{
+					Comment: &core.Comment{
+						Line: "//",
+					},
+				},
+			},
+		},
+	}
+	if err := c.Write(configuration.WithFormat(configuration.YAML)); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	data, err := os.ReadFile("emits.yaml")
+	if err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if !strings.HasPrefix(string(data), "name: lorem\n") {
+		t.Errorf("Expecting yaml document, got %s", data)
+	}
+	loaded := &configuration.Configuration{}
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Expecting nil, got %v", err)
+	}
+	if errs := loaded.Validate(); errs != nil {
+		t.Errorf("Expecting nil, got %v", errs)
+	}
+	os.Rename("emits.yaml", "emits.yml")
+	loaded = &configuration.Configuration{}
+	if err := loaded.Load(); err != nil || loaded.Name != "lorem" {
+		t.Errorf("Expecting lorem, got %v %v", err, loaded.Name)
+	}
+	if err := c.Write(configuration.WithFormat("unknown")); err == nil {
+		t.Errorf("Expecting error, got nil")
+	}
+}